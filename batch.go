@@ -0,0 +1,193 @@
+package ssn
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ErrColumn is returned when a named CSV column is missing from the header
+var ErrColumn = errors.New("Column not found in header")
+
+// RowError ties an error to the row of bulk input it was found on
+type RowError struct {
+	Row int
+	Err error
+	// Warning is set when the row was accepted anyway, see BatchOptions
+	Warning bool
+}
+
+func (e *RowError) Error() string {
+	if e.Warning {
+		return fmt.Sprintf("row %d: %v (accepted)", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// Unwrap returns the underlying error so errors.Is works on RowError
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// BatchOptions configures the bulk parsers
+type BatchOptions struct {
+	// IgnoreChecksum accepts rows with an incorrect checksum, reporting them
+	// as a *RowError with Warning set, while format and date are still validated
+	IgnoreChecksum bool
+}
+
+// parseRow parses one SSN of bulk input, returning the SSN to keep and the error to report
+func parseRow(s string, row int, opts BatchOptions) (*SSN, error) {
+	ssn, err := NewSSNFromString(s)
+	if err == nil {
+		return ssn, nil
+	}
+	if err == ErrChecksum && opts.IgnoreChecksum {
+		// Coordination numbers are rejected whatever their checksum
+		if ssn.Kind() == KindCoordination {
+			return nil, &RowError{Row: row, Err: ErrCoordinationNumber}
+		}
+		return ssn, &RowError{Row: row, Err: err, Warning: true}
+	}
+	return nil, &RowError{Row: row, Err: err}
+}
+
+// ParseCSV reads a CSV with a header row and parses the SSNs found in the named column.
+// Rows that fail are reported as *RowError where Row counts lines of records
+// the way a spreadsheet does, i.e. the header is row 1 and the first record row 2.
+func ParseCSV(r io.Reader, column string) ([]*SSN, []error) {
+	return ParseCSVWithOptions(r, column, BatchOptions{})
+}
+
+// ParseCSVWithOptions is ParseCSV with the leniency given by opts
+func ParseCSVWithOptions(r io.Reader, column string, opts BatchOptions) ([]*SSN, []error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, []error{err}
+	}
+	col := -1
+	for i, name := range header {
+		if strings.TrimSpace(name) == column {
+			col = i
+			break
+		}
+	}
+	if col < 0 {
+		return nil, []error{ErrColumn}
+	}
+	var (
+		ssns []*SSN
+		errs []error
+	)
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, &RowError{Row: row, Err: err})
+			var pe *csv.ParseError
+			if errors.As(err, &pe) {
+				continue
+			}
+			break
+		}
+		ssn, err := parseRow(strings.TrimSpace(record[col]), row, opts)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if ssn != nil {
+			ssns = append(ssns, ssn)
+		}
+	}
+	return ssns, errs
+}
+
+// ParseAll reads one SSN per line, as accepted by NewSSNFromString, skipping blank lines.
+// Lines that fail are reported as *RowError with Row being the line number, counted from 1.
+func ParseAll(r io.Reader) ([]*SSN, []error) {
+	return ParseAllWithOptions(r, BatchOptions{})
+}
+
+// ParseAllWithOptions is ParseAll with the leniency given by opts
+func ParseAllWithOptions(r io.Reader, opts BatchOptions) ([]*SSN, []error) {
+	var (
+		ssns []*SSN
+		errs []error
+	)
+	scanner := bufio.NewScanner(r)
+	for row := 1; scanner.Scan(); row++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		ssn, err := parseRow(line, row, opts)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if ssn != nil {
+			ssns = append(ssns, ssn)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return ssns, errs
+}
+
+// FormatOptions holds the arguments of SSN.Format
+type FormatOptions struct {
+	Century bool
+	Dash    bool
+}
+
+// WriteAll writes the SSNs to w one per line, formatted according to opts
+func WriteAll(w io.Writer, ssns []*SSN, opts FormatOptions) error {
+	bw := bufio.NewWriter(w)
+	for _, ssn := range ssns {
+		bw.WriteString(ssn.Format(opts.Century, opts.Dash))
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// Partition parses every input like NewSSNFromString and splits the results into the valid SSNs,
+// in input order, and the failing inputs mapped to their errors
+func Partition(inputs []string) (valid []*SSN, invalid map[string]error) {
+	invalid = make(map[string]error)
+	for _, s := range inputs {
+		ssn, err := NewSSNFromString(s)
+		if err != nil {
+			invalid[s] = err
+			continue
+		}
+		valid = append(valid, ssn)
+	}
+	return valid, invalid
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// ExtractAll finds the SSNs in the YYYYMMDD-XXXX or YYYYMMDDXXXX form in arbitrary text,
+// e.g. to audit logs for leaked SSNs. Candidates that are part of longer digit runs
+// or fail validation, including the checksum, are left out.
+func ExtractAll(text string) []*SSN {
+	var re = regexp.MustCompile(`[0-9]{8}-?[0-9]{4}`)
+	var ssns []*SSN
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		if (loc[0] > 0 && isDigit(text[loc[0]-1])) || (loc[1] < len(text) && isDigit(text[loc[1]])) {
+			continue
+		}
+		if ssn, err := NewSSNFromString(text[loc[0]:loc[1]]); err == nil {
+			ssns = append(ssns, ssn)
+		}
+	}
+	return ssns
+}
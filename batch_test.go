@@ -0,0 +1,163 @@
+package ssn
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	input := "name,ssn,city\n" +
+		"Anna,19750930-1938,Lund\n" +
+		"Bo,20090301-6684,Umeå\n" +
+		"Cid, 201105304933 ,Kiruna\n"
+	ssns, errs := ParseCSV(strings.NewReader(input), "ssn")
+	if len(ssns) != 2 {
+		t.Fatal("Want 2 SSNs, got", ssns)
+	}
+	assert(ssns[0].String(), "19750930-1938", t)
+	assert(ssns[1].String(), "20110530-4933", t)
+	if len(errs) != 1 {
+		t.Fatal("Want 1 error, got", errs)
+	}
+	var re *RowError
+	if !errors.As(errs[0], &re) {
+		t.Fatal("Want RowError, got", errs[0])
+	}
+	assert(re.Row, 3, t)
+	assert(re.Err, ErrChecksum, t)
+}
+
+func TestParseCSVMissingColumn(t *testing.T) {
+	ssns, errs := ParseCSV(strings.NewReader("name,city\nAnna,Lund\n"), "ssn")
+	if ssns != nil || len(errs) != 1 || errs[0] != ErrColumn {
+		t.Errorf(util, "missing column", errs, ErrColumn)
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	input := "19750930-1938\n\n  201105304933\n20090301-6684\nnonsense\n"
+	ssns, errs := ParseAll(strings.NewReader(input))
+	if len(ssns) != 2 {
+		t.Fatal("Want 2 SSNs, got", ssns)
+	}
+	assert(ssns[1].String(), "20110530-4933", t)
+	if len(errs) != 2 {
+		t.Fatal("Want 2 errors, got", errs)
+	}
+	for i, want := range []RowError{{Row: 4, Err: ErrChecksum}, {Row: 5, Err: ErrFormat}} {
+		var re *RowError
+		if !errors.As(errs[i], &re) || *re != want {
+			t.Errorf(util, i, errs[i], want)
+		}
+	}
+}
+
+func TestWriteAllParseAll(t *testing.T) {
+	ssns := []*SSN{NewRandomSSN(), NewSafeRandomSSN(), NewRandomSSN()}
+	for _, opts := range []FormatOptions{{true, true}, {true, false}} {
+		var buf bytes.Buffer
+		if err := WriteAll(&buf, ssns, opts); err != nil {
+			t.Fatal("Could not write", err)
+		}
+		got, errs := ParseAll(&buf)
+		if len(errs) != 0 {
+			t.Fatal("Could not parse", errs)
+		}
+		if len(got) != len(ssns) {
+			t.Fatal("Want", len(ssns), "SSNs, got", len(got))
+		}
+		for i := range ssns {
+			assert(*got[i], *ssns[i], t)
+		}
+	}
+	var buf bytes.Buffer
+	WriteAll(&buf, ssns[:1], FormatOptions{})
+	assert(buf.String(), ssns[0].Format(false, false)+"\n", t)
+}
+
+func TestPartition(t *testing.T) {
+	valid, invalid := Partition([]string{
+		"19750930-1938",
+		"",
+		"198A0930-1938",
+		"20101510-1234",
+		"20090301-6684",
+		"201105304933",
+	})
+	if len(valid) != 2 {
+		t.Fatal("Want 2 valid SSNs, got", valid)
+	}
+	assert(valid[0].String(), "19750930-1938", t)
+	assert(valid[1].String(), "20110530-4933", t)
+	want := map[string]error{
+		"":              ErrEmpty,
+		"198A0930-1938": ErrFormat,
+		"20101510-1234": ErrDate,
+		"20090301-6684": ErrChecksum,
+	}
+	assert(len(invalid), len(want), t)
+	for input, err := range want {
+		assert(invalid[input], err, t)
+	}
+}
+
+func TestExtractAll(t *testing.T) {
+	text := "user=19750930-1938 logged in; retry for 20090301-6684 (bad checksum)\n" +
+		"id:201105304933, order 1197509301938 and phone 0701234567."
+	ssns := ExtractAll(text)
+	if len(ssns) != 2 {
+		t.Fatal("Want 2 SSNs, got", ssns)
+	}
+	assert(ssns[0].String(), "19750930-1938", t)
+	assert(ssns[1].String(), "20110530-4933", t)
+	assert(len(ExtractAll("nothing to see")), 0, t)
+}
+
+func TestIgnoreChecksum(t *testing.T) {
+	lines := "19750930-1938\n20090301-6684\n20101510-1234\n"
+	csvInput := "ssn\n" + lines
+	tests := map[string]func(BatchOptions) ([]*SSN, []error){
+		"ParseAll": func(opts BatchOptions) ([]*SSN, []error) {
+			return ParseAllWithOptions(strings.NewReader(lines), opts)
+		},
+		"ParseCSV": func(opts BatchOptions) ([]*SSN, []error) {
+			return ParseCSVWithOptions(strings.NewReader(csvInput), "ssn", opts)
+		},
+	}
+	for label, parse := range tests {
+		t.Run(label, func(t *testing.T) {
+			ssns, errs := parse(BatchOptions{})
+			assert(len(ssns), 1, t)
+			assert(len(errs), 2, t)
+
+			ssns, errs = parse(BatchOptions{IgnoreChecksum: true})
+			if len(ssns) != 2 {
+				t.Fatal("Want 2 SSNs, got", ssns)
+			}
+			assert(ssns[1].String(), "20090301-6684", t)
+			if len(errs) != 2 {
+				t.Fatal("Want 2 errors, got", errs)
+			}
+			var warning, failure *RowError
+			if !errors.As(errs[0], &warning) || !errors.As(errs[1], &failure) {
+				t.Fatal("Want RowErrors, got", errs)
+			}
+			assert(warning.Err, ErrChecksum, t)
+			assert(warning.Warning, true, t)
+			assert(failure.Err, ErrDate, t)
+			assert(failure.Warning, false, t)
+		})
+	}
+
+	// A coordination number is rejected whether or not its checksum is correct
+	ssns, errs := ParseAllWithOptions(strings.NewReader("19750390-1931\n19750390-1932\n"), BatchOptions{IgnoreChecksum: true})
+	assert(len(ssns), 0, t)
+	if len(errs) != 2 {
+		t.Fatal("Want 2 errors, got", errs)
+	}
+	for _, err := range errs {
+		assert(errors.Is(err, ErrCoordinationNumber), true, t)
+	}
+}
@@ -0,0 +1,95 @@
+package ssn
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Errors for county encoding
+var (
+	ErrCounty   = errors.New("Unknown county")
+	ErrNoCounty = errors.New("County is only encoded for people born before 1990")
+)
+
+// countyRange maps the first two birth number digits to a county (födelselän)
+type countyRange struct {
+	from, to int
+	name     string
+}
+
+// counties is the county encoding used for people born before 1990.
+// The codes 65, 74 and 93-99 are extra numbers and not tied to a county.
+var counties = []countyRange{
+	{0, 13, "Stockholms län"},
+	{14, 15, "Uppsala län"},
+	{16, 18, "Södermanlands län"},
+	{19, 23, "Östergötlands län"},
+	{24, 26, "Jönköpings län"},
+	{27, 28, "Kronobergs län"},
+	{29, 31, "Kalmar län"},
+	{32, 32, "Gotlands län"},
+	{33, 34, "Blekinge län"},
+	{35, 38, "Kristianstads län"},
+	{39, 45, "Malmöhus län"},
+	{46, 47, "Hallands län"},
+	{48, 54, "Göteborgs och Bohus län"},
+	{55, 58, "Älvsborgs län"},
+	{59, 61, "Skaraborgs län"},
+	{62, 64, "Värmlands län"},
+	{66, 68, "Örebro län"},
+	{69, 70, "Västmanlands län"},
+	{71, 73, "Kopparbergs län"},
+	{75, 77, "Gävleborgs län"},
+	{78, 81, "Västernorrlands län"},
+	{82, 84, "Jämtlands län"},
+	{85, 88, "Västerbottens län"},
+	{89, 92, "Norrbottens län"},
+}
+
+// lastCountyYear is the last birth year with the county encoded in the birth number
+const lastCountyYear = 1989
+
+// BirthCounty returns the county of birth encoded in the first two birth number digits.
+// ok is false for people born 1990 or later and for extra numbers not tied to a county.
+func (n SSN) BirthCounty() (county string, ok bool) {
+	if year, _, _ := n.Date(); year > lastCountyYear {
+		return "", false
+	}
+	code := intSliceToInt(n[8:10])
+	for _, c := range counties {
+		if code >= c.from && code <= c.to {
+			return c.name, true
+		}
+	}
+	return "", false
+}
+
+// NewSSNForCounty will return a SSN born on a random day of year with a birth number
+// encoding county, as named by BirthCounty. The year must be before 1990.
+func NewSSNForCounty(county string, year int) (*SSN, error) {
+	if year > lastCountyYear {
+		return nil, ErrNoCounty
+	}
+	for _, c := range counties {
+		if c.name != county {
+			continue
+		}
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		days := int(start.AddDate(1, 0, 0).Sub(start).Hours() / 24)
+		t := start.AddDate(0, 0, rng.Intn(days))
+		code := c.from + rng.Intn(c.to-c.from+1)
+		return NewSSN(t.Year(), t.Month(), t.Day(), code*10+rng.Intn(10))
+	}
+	return nil, ErrCounty
+}
+
+// Counties returns each county named by BirthCounty with its range of birth number codes,
+// such as "Stockholms län": "00-13", in a new map the caller may modify
+func Counties() map[string]string {
+	m := make(map[string]string, len(counties))
+	for _, c := range counties {
+		m[c.name] = fmt.Sprintf("%02d-%02d", c.from, c.to)
+	}
+	return m
+}
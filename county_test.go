@@ -0,0 +1,61 @@
+package ssn
+
+import "testing"
+
+func TestNewSSNForCounty(t *testing.T) {
+	for _, c := range counties {
+		t.Run(c.name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				ssn, err := NewSSNForCounty(c.name, 1975)
+				if err != nil {
+					t.Fatal("Could not make SSN", err)
+				}
+				county, ok := ssn.BirthCounty()
+				assert(ok, true, t)
+				assert(county, c.name, t)
+				assert(ssn.Format(true, false)[:4], "1975", t)
+				assert(ssn[11], GetChecksum(*ssn), t)
+			}
+		})
+	}
+}
+
+func TestNewSSNForCountyInvalid(t *testing.T) {
+	if _, err := NewSSNForCounty("Gotlands län", 1990); err != ErrNoCounty {
+		t.Errorf(util, "1990", err, ErrNoCounty)
+	}
+	if _, err := NewSSNForCounty("Atlantis län", 1975); err != ErrCounty {
+		t.Errorf(util, "unknown", err, ErrCounty)
+	}
+}
+
+func TestBirthCounty(t *testing.T) {
+	tests := []struct {
+		ssn    SSN
+		county string
+		ok     bool
+	}{
+		{SSN{1, 9, 7, 5, 0, 9, 3, 0, 3, 2, 1, 0}, "Gotlands län", true},
+		{SSN{1, 9, 7, 5, 0, 9, 3, 0, 0, 0, 1, 0}, "Stockholms län", true},
+		{SSN{1, 9, 7, 5, 0, 9, 3, 0, 9, 2, 1, 0}, "Norrbottens län", true},
+		{SSN{1, 9, 7, 5, 0, 9, 3, 0, 6, 5, 1, 0}, "", false},
+		{SSN{1, 9, 7, 5, 0, 9, 3, 0, 9, 8, 1, 0}, "", false},
+		{SSN{1, 9, 9, 0, 0, 9, 3, 0, 3, 2, 1, 0}, "", false},
+	}
+	for i, tc := range tests {
+		county, ok := tc.ssn.BirthCounty()
+		if county != tc.county || ok != tc.ok {
+			t.Errorf(util, i, county, tc.county)
+		}
+	}
+}
+
+func TestCounties(t *testing.T) {
+	m := Counties()
+	assert(len(m), 24, t)
+	assert(m["Stockholms län"], "00-13", t)
+	assert(m["Gotlands län"], "32-32", t)
+	assert(m["Norrbottens län"], "89-92", t)
+	m["Stockholms län"] = "changed"
+	assert(Counties()["Stockholms län"], "00-13", t)
+}
@@ -0,0 +1,45 @@
+package ssn
+
+import (
+	"errors"
+	"time"
+)
+
+// Errors for MatchesCriteria
+var (
+	ErrGenderMismatch = errors.New("Gender does not match")
+	ErrTooYoung       = errors.New("Person is younger than the minimum age")
+	ErrTooOld         = errors.New("Person is older than the maximum age")
+)
+
+// Criteria are constraints for MatchesCriteria. Zero fields are not checked.
+type Criteria struct {
+	// Gender is the required gender
+	Gender Gender
+	// MinAge is the lowest accepted age in whole years
+	MinAge int
+	// MaxAge is the highest accepted age in whole years
+	MaxAge int
+	// On is the date ages are counted on, the current date if zero
+	On time.Time
+}
+
+// MatchesCriteria checks n against c in the order gender, minimum age, maximum age,
+// and returns ErrGenderMismatch, ErrTooYoung or ErrTooOld for the first unmet constraint
+func (n SSN) MatchesCriteria(c Criteria) error {
+	if c.Gender != 0 && c.Gender != n.DerivedGender() {
+		return ErrGenderMismatch
+	}
+	on := c.On
+	if on.IsZero() {
+		on = clock()
+	}
+	age := n.AgeYears(on)
+	if c.MinAge != 0 && age < c.MinAge {
+		return ErrTooYoung
+	}
+	if c.MaxAge != 0 && age > c.MaxAge {
+		return ErrTooOld
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package ssn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchesCriteria(t *testing.T) {
+	ssn := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	on := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		c   Criteria
+		err error
+	}{
+		"No criteria":     {Criteria{}, nil},
+		"All met":         {Criteria{Gender: Male, MinAge: 18, MaxAge: 65, On: on}, nil},
+		"Exact ages":      {Criteria{MinAge: 49, MaxAge: 49, On: on}, nil},
+		"Wrong gender":    {Criteria{Gender: Female, MinAge: 18, On: on}, ErrGenderMismatch},
+		"Too young":       {Criteria{Gender: Male, MinAge: 50, On: on}, ErrTooYoung},
+		"Too old":         {Criteria{MinAge: 18, MaxAge: 48, On: on}, ErrTooOld},
+		"Gender first":    {Criteria{Gender: Female, MinAge: 50, On: on}, ErrGenderMismatch},
+		"Current date":    {Criteria{MinAge: 49}, nil},
+		"Before birthday": {Criteria{MinAge: 50, On: time.Date(2025, 9, 29, 0, 0, 0, 0, time.UTC)}, ErrTooYoung},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			assert(ssn.MatchesCriteria(tc.c), tc.err, t)
+		})
+	}
+}
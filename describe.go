@@ -0,0 +1,162 @@
+package ssn
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	_ "time/tzdata" // Europe/Stockholm must load even without a system time zone database
+)
+
+// BirthDateISO returns the birth date in the ISO 8601 YYYY-MM-DD format
+func (n SSN) BirthDateISO() string {
+	return n.Time().Format("2006-01-02")
+}
+
+var (
+	stockholmOnce sync.Once
+	stockholm     *time.Location
+)
+
+// swedishTime returns the Europe/Stockholm location from the embedded time zone database
+func swedishTime() *time.Location {
+	stockholmOnce.Do(func() {
+		loc, err := time.LoadLocation("Europe/Stockholm")
+		if err != nil {
+			panic(err)
+		}
+		stockholm = loc
+	})
+	return stockholm
+}
+
+// BirthTimeRFC3339 returns midnight Swedish time on the birth date in RFC 3339 format,
+// such as 1975-09-30T00:00:00+01:00, for log schemas requiring a full timestamp
+func (n SSN) BirthTimeRFC3339() string {
+	year, month, day := n.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, swedishTime()).Format(time.RFC3339)
+}
+
+// BirthDateLocalized returns the birth date formatted with a Go time layout such as "02/01/2006"
+func (n SSN) BirthDateLocalized(layout string) string {
+	return n.Time().Format(layout)
+}
+
+// Describe returns a short English summary such as "Female, born 1975-09-30, 49 years old"
+func (n SSN) Describe() string {
+	gender := "Male"
+	if n.Female() {
+		gender = "Female"
+	}
+	return fmt.Sprintf("%s, born %s, %d years old", gender, n.BirthDateISO(), n.AgeYears(clock()))
+}
+
+// DescribeSv returns a short Swedish summary such as "Kvinna, född 1975-09-30, 49 år"
+func (n SSN) DescribeSv() string {
+	gender := "Man"
+	if n.Female() {
+		gender = "Kvinna"
+	}
+	return fmt.Sprintf("%s, född %s, %d år", gender, n.BirthDateISO(), n.AgeYears(clock()))
+}
+
+// GeneralizeLevel is how coarse a date SSN.Generalize returns
+type GeneralizeLevel int
+
+// Generalization levels, from the full birth date to the decade of birth
+const (
+	// GeneralizeFull is the full birth date, 1975-09-30
+	GeneralizeFull GeneralizeLevel = iota
+	// GeneralizeYearMonth is the year and month of birth, 1975-09
+	GeneralizeYearMonth
+	// GeneralizeYear is the year of birth, 1975
+	GeneralizeYear
+	// GeneralizeDecade is the decade of birth, 1970s
+	GeneralizeDecade
+)
+
+// Generalize returns the birth date at the coarseness of level, never including the birth number,
+// for publishing data that must not expose exact dates
+func (n SSN) Generalize(level GeneralizeLevel) string {
+	year, month, day := n.Date()
+	switch level {
+	case GeneralizeYearMonth:
+		return fmt.Sprintf("%04d-%02d", year, month)
+	case GeneralizeYear:
+		return fmt.Sprintf("%04d", year)
+	case GeneralizeDecade:
+		return fmt.Sprintf("%04ds", year/10*10)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+}
+
+// monthNamesSv are the Swedish month names, which are not capitalized
+var monthNamesSv = [12]string{
+	"januari", "februari", "mars", "april", "maj", "juni",
+	"juli", "augusti", "september", "oktober", "november", "december",
+}
+
+// MonthNameSv returns the Swedish name of the birth month, "januari" to "december"
+func (n SSN) MonthNameSv() string {
+	_, month, _ := n.Date()
+	return monthNamesSv[month-1]
+}
+
+// starSigns holds, per month, the day the western zodiac sign starting in that month begins
+var starSigns = [12]struct {
+	start int
+	sign  string
+}{
+	{20, "Aquarius"},
+	{19, "Pisces"},
+	{21, "Aries"},
+	{20, "Taurus"},
+	{21, "Gemini"},
+	{21, "Cancer"},
+	{23, "Leo"},
+	{23, "Virgo"},
+	{23, "Libra"},
+	{23, "Scorpio"},
+	{22, "Sagittarius"},
+	{22, "Capricorn"},
+}
+
+// StarSign returns the western zodiac sign of the birth date
+func (n SSN) StarSign() string {
+	_, m, d := n.Date()
+	i := int(m) - 1
+	if d < starSigns[i].start {
+		i = (i + 11) % 12
+	}
+	return starSigns[i].sign
+}
+
+// Ages at which the Swedish school stages start, used by SchoolStage
+var (
+	PreschoolAge     = 1  // förskola
+	LowerStageAge    = 7  // lågstadiet, grades 1-3
+	MiddleStageAge   = 10 // mellanstadiet, grades 4-6
+	UpperStageAge    = 13 // högstadiet, grades 7-9
+	GymnasiumAge     = 16 // gymnasium
+	SchoolLeavingAge = 19 // no longer in school
+)
+
+// SchoolStage returns the Swedish school stage for the age on the date of on:
+// "förskola", "lågstadiet", "mellanstadiet", "högstadiet", "gymnasium" or "none"
+func (n SSN) SchoolStage(on time.Time) string {
+	age := n.AgeYears(on)
+	switch {
+	case age < PreschoolAge:
+		return "none"
+	case age < LowerStageAge:
+		return "förskola"
+	case age < MiddleStageAge:
+		return "lågstadiet"
+	case age < UpperStageAge:
+		return "mellanstadiet"
+	case age < GymnasiumAge:
+		return "högstadiet"
+	case age < SchoolLeavingAge:
+		return "gymnasium"
+	}
+	return "none"
+}
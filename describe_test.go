@@ -0,0 +1,117 @@
+package ssn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBirthDateISO(t *testing.T) {
+	assert(SSN{2, 0, 0, 9, 0, 3, 0, 1, 6, 6, 8, 1}.BirthDateISO(), "2009-03-01", t)
+}
+
+func TestBirthTimeRFC3339(t *testing.T) {
+	assert(SSN{1, 9, 8, 5, 0, 7, 1, 5, 1, 2, 3, 4}.BirthTimeRFC3339(), "1985-07-15T00:00:00+02:00", t)
+	assert(SSN{2, 0, 0, 9, 0, 1, 1, 5, 6, 6, 8, 1}.BirthTimeRFC3339(), "2009-01-15T00:00:00+01:00", t)
+}
+
+func TestBirthDateLocalized(t *testing.T) {
+	ssn := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	tests := map[string]struct {
+		layout string
+		want   string
+	}{
+		"ISO":  {"2006-01-02", "1975-09-30"},
+		"UK":   {"02/01/2006", "30/09/1975"},
+		"US":   {"01/02/2006", "09/30/1975"},
+		"Long": {"Monday, January 2, 2006", "Tuesday, September 30, 1975"},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			assert(ssn.BirthDateLocalized(tc.layout), tc.want, t)
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	defer func() { clock = time.Now }()
+	clock = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	female := SSN{1, 9, 7, 2, 0, 5, 2, 5, 6, 6, 0, 0}
+	male := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	assert(female.Describe(), "Female, born 1972-05-25, 53 years old", t)
+	assert(male.Describe(), "Male, born 1975-09-30, 49 years old", t)
+	assert(female.DescribeSv(), "Kvinna, född 1972-05-25, 53 år", t)
+	assert(male.DescribeSv(), "Man, född 1975-09-30, 49 år", t)
+}
+
+func TestGeneralize(t *testing.T) {
+	ssn := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	tests := map[string]struct {
+		level GeneralizeLevel
+		want  string
+	}{
+		"Full":       {GeneralizeFull, "1975-09-30"},
+		"Year month": {GeneralizeYearMonth, "1975-09"},
+		"Year":       {GeneralizeYear, "1975"},
+		"Decade":     {GeneralizeDecade, "1970s"},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			assert(ssn.Generalize(tc.level), tc.want, t)
+		})
+	}
+	assert(SSN{2, 0, 0, 9, 0, 3, 0, 1, 6, 6, 8, 1}.Generalize(GeneralizeDecade), "2000s", t)
+}
+
+func TestMonthNameSv(t *testing.T) {
+	assert(SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}.MonthNameSv(), "september", t)
+	assert(SSN{2, 0, 0, 9, 0, 3, 0, 1, 6, 6, 8, 1}.MonthNameSv(), "mars", t)
+	assert(SSN{1, 9, 7, 2, 0, 5, 2, 5, 6, 6, 0, 0}.MonthNameSv(), "maj", t)
+	assert(SSN{1, 9, 9, 9, 1, 2, 3, 1, 1, 2, 3, 1}.MonthNameSv(), "december", t)
+}
+
+func TestStarSign(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"19750930", "Libra"},
+		{"19750922", "Virgo"},
+		{"19750923", "Libra"},
+		{"19750119", "Capricorn"},
+		{"19750120", "Aquarius"},
+		{"19751221", "Sagittarius"},
+		{"19751222", "Capricorn"},
+		{"19750320", "Pisces"},
+		{"19750321", "Aries"},
+	}
+	for _, tc := range tests {
+		ssn, err := NewSSNFromStrings(tc.date, "0000")
+		if ssn == nil {
+			t.Fatal("Could not make SSN", err)
+		}
+		assert(ssn.StarSign(), tc.want, t)
+	}
+}
+
+func TestSchoolStage(t *testing.T) {
+	on := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		age  int
+		want string
+	}{
+		{0, "none"},
+		{3, "förskola"},
+		{7, "lågstadiet"},
+		{11, "mellanstadiet"},
+		{15, "högstadiet"},
+		{16, "gymnasium"},
+		{40, "none"},
+	}
+	for _, tc := range tests {
+		ssn := NewSSNFromTime(on.AddDate(-tc.age, 0, 0), false)
+		assert(ssn.SchoolStage(on), tc.want, t)
+	}
+	defer func(age int) { LowerStageAge = age }(LowerStageAge)
+	LowerStageAge = 6
+	assert(NewSSNFromTime(on.AddDate(-6, 0, 0), false).SchoolStage(on), "lågstadiet", t)
+}
@@ -0,0 +1,24 @@
+package ssn
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+)
+
+// SHA256Hex returns the hex encoded SHA-256 digest of the canonical YYYYMMDD-XXXX string.
+// It is one-way and meant for correlating log lines without storing the SSN itself.
+func (n SSN) SHA256Hex() string {
+	sum := sha256.Sum256([]byte(n.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// FNV32 returns the 32 bit FNV-1a hash of the 12 digits without separator, e.g. "197509301938",
+// as ASCII bytes. That is, starting from the offset basis 2166136261, for each byte the hash is
+// XORed with the byte and then multiplied by the prime 16777619 modulo 2^32.
+// Other languages can reproduce it to bucket SSNs consistently.
+func (n SSN) FNV32() uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(n.Format(true, false)))
+	return h.Sum32()
+}
@@ -0,0 +1,13 @@
+package ssn
+
+import "testing"
+
+func TestSHA256Hex(t *testing.T) {
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	assert(pnr.SHA256Hex(), "5bc20bc085e8775121f4dd32494cb0be3d5bfe48c6387a9f4bfc4e4c89d35290", t)
+}
+
+func TestFNV32(t *testing.T) {
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	assert(pnr.FNV32(), uint32(0x56740712), t)
+}
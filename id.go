@@ -0,0 +1,80 @@
+package ssn
+
+import "strconv"
+
+// Bit layout of ID64, least significant bits first:
+//
+//	bits  0-3   checksum     (4 bits)
+//	bits  4-13  birth number (10 bits)
+//	bits 14-20  day          (7 bits, room for coordination numbers)
+//	bits 21-24  month        (4 bits)
+//	bits 25-38  year         (14 bits)
+//
+// The remaining high bits are always zero.
+const (
+	idChecksumShift = 0
+	idBirthShift    = 4
+	idDayShift      = 14
+	idMonthShift    = 21
+	idYearShift     = 25
+	idEnd           = 39
+)
+
+// ID64 packs the SSN into an integer with the stable layout documented above.
+// It is not a hash, FromID64 gives back the same SSN.
+func (n SSN) ID64() uint64 {
+	return uint64(intSliceToInt(n[0:4]))<<idYearShift |
+		uint64(intSliceToInt(n[4:6]))<<idMonthShift |
+		uint64(intSliceToInt(n[6:8]))<<idDayShift |
+		uint64(intSliceToInt(n[8:11]))<<idBirthShift |
+		uint64(n[11])<<idChecksumShift
+}
+
+// FromID64 unpacks an SSN packed by ID64, ErrFormat is returned if a field is out of range
+func FromID64(id uint64) (SSN, error) {
+	field := func(shift, end uint) int {
+		return int(id >> shift & (1<<(end-shift) - 1))
+	}
+	year := field(idYearShift, idEnd)
+	month := field(idMonthShift, idYearShift)
+	day := field(idDayShift, idMonthShift)
+	birthNumber := field(idBirthShift, idDayShift)
+	checksum := field(idChecksumShift, idBirthShift)
+	var n SSN
+	if id>>idEnd != 0 || year > 9999 || month < 1 || month > 12 || day < 1 || day > 91 ||
+		birthNumber > 999 || checksum > 9 {
+		return n, ErrFormat
+	}
+	for i, v := range []int{year / 1000, year / 100, year / 10, year, month / 10, month,
+		day / 10, day, birthNumber / 100, birthNumber / 10, birthNumber, checksum} {
+		n[i] = v % 10
+	}
+	return n, nil
+}
+
+// ShardKey returns a stable bucket in [0, buckets) derived from ID64, for routing by person.
+// It panics if buckets is not positive.
+func (n SSN) ShardKey(buckets int) int {
+	if buckets <= 0 {
+		panic("ShardKey needs a positive number of buckets")
+	}
+	return int(n.ID64() % uint64(buckets))
+}
+
+// Int64 returns the 12 digits of the SSN read as a decimal number, 197509301938 for 19750930-1938
+func (n SSN) Int64() int64 {
+	return int64(intSliceToInt(n[:]))
+}
+
+// FromInt64 makes an SSN from its 12 digit decimal number, validating it like NewSSNFromString.
+// Numbers without exactly 12 digits give ErrFormat.
+func FromInt64(v int64) (SSN, error) {
+	if v < 1e11 || v > 1e12-1 {
+		return SSN{}, ErrFormat
+	}
+	ssn, err := NewSSNFromString(strconv.FormatInt(v, 10))
+	if ssn == nil {
+		return SSN{}, err
+	}
+	return *ssn, err
+}
@@ -0,0 +1,82 @@
+package ssn
+
+import "testing"
+
+func TestID64RoundTrip(t *testing.T) {
+	tests := []SSN{
+		{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8},
+		{2, 0, 0, 9, 0, 3, 0, 1, 6, 6, 8, 1},
+		{0, 0, 0, 1, 0, 1, 0, 1, 0, 0, 0, 0},
+		{9, 9, 9, 9, 1, 2, 9, 1, 9, 9, 9, 9},
+	}
+	for i, tc := range tests {
+		got, err := FromID64(tc.ID64())
+		if err != nil || got != tc {
+			t.Errorf(util, i, got, tc)
+		}
+	}
+}
+
+func TestID64Layout(t *testing.T) {
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	want := uint64(1975)<<25 | 9<<21 | 30<<14 | 193<<4 | 8
+	assert(pnr.ID64(), want, t)
+}
+
+func TestFromID64Invalid(t *testing.T) {
+	tests := map[string]uint64{
+		"month zero":     uint64(1975)<<25 | 30<<14,
+		"month 13":       uint64(1975)<<25 | 13<<21 | 30<<14,
+		"day 92":         uint64(1975)<<25 | 9<<21 | 92<<14,
+		"birth number":   uint64(1975)<<25 | 9<<21 | 30<<14 | 1000<<4,
+		"checksum":       uint64(1975)<<25 | 9<<21 | 30<<14 | 10,
+		"year":           uint64(10000)<<25 | 9<<21 | 30<<14,
+		"high bits used": 1<<39 | uint64(1975)<<25 | 9<<21 | 30<<14,
+	}
+	for label, id := range tests {
+		if _, err := FromID64(id); err != ErrFormat {
+			t.Errorf(util, label, err, ErrFormat)
+		}
+	}
+}
+
+func TestShardKey(t *testing.T) {
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	assert(pnr.ShardKey(16), int(pnr.ID64()%16), t)
+	for _, buckets := range []int{1, 2, 7, 16, 1000} {
+		for i := 0; i < 20; i++ {
+			ssn := NewRandomSSN()
+			key := ssn.ShardKey(buckets)
+			if key < 0 || key >= buckets {
+				t.Errorf(util, ssn, key, buckets)
+			}
+			assert(ssn.ShardKey(buckets), key, t)
+		}
+	}
+}
+
+func TestInt64(t *testing.T) {
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	assert(pnr.Int64(), int64(197509301938), t)
+	got, err := FromInt64(pnr.Int64())
+	assert(err, nil, t)
+	assert(got, pnr, t)
+}
+
+func TestFromInt64Invalid(t *testing.T) {
+	tests := map[string]struct {
+		v   int64
+		err error
+	}{
+		"too short": {7509301938, ErrFormat},
+		"too long":  {1197509301938, ErrFormat},
+		"negative":  {-197509301938, ErrFormat},
+		"bad date":  {201015101234, ErrDate},
+		"checksum":  {197509301939, ErrChecksum},
+	}
+	for label, tc := range tests {
+		if _, err := FromInt64(tc.v); err != tc.err {
+			t.Errorf(util, label, err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package ssn
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+)
+
+// MarshalMasked makes MarshalJSON emit the masked YYYYMMDD-**** form,
+// for services that must never return full SSNs
+var MarshalMasked bool
+
+// MarshalJSON encodes the SSN as a JSON string in the standard YYYYMMDD-XXXX format,
+// or as YYYYMMDD-**** if MarshalMasked is set
+func (n SSN) MarshalJSON() ([]byte, error) {
+	if MarshalMasked {
+		return json.Marshal(n.Masked())
+	}
+	return json.Marshal(n.String())
+}
+
+// MarshalVerbose encodes the SSN as a JSON object with derived fields for debugging, such as
+// {"ssn":"19750930-1938","birthDate":"1975-09-30","gender":"male","age":49}. It is never masked.
+func (n SSN) MarshalVerbose() ([]byte, error) {
+	return json.Marshal(struct {
+		SSN       string `json:"ssn"`
+		BirthDate string `json:"birthDate"`
+		Gender    string `json:"gender"`
+		Age       int    `json:"age"`
+	}{n.String(), n.BirthDateISO(), n.DerivedGender().String(), n.AgeYears(clock())})
+}
+
+// UnmarshalJSON decodes an SSN from either a JSON string or a 12 digit JSON number
+// such as 197509301938. The value is validated the same way as in NewSSNFromString, except that
+// coordination numbers are accepted so that they survive a round trip through MarshalJSON.
+func (n *SSN) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	} else {
+		if len(data) != 12 {
+			return ErrFormat
+		}
+		for _, b := range data {
+			if b < '0' || b > '9' {
+				return ErrFormat
+			}
+		}
+		s = string(data)
+	}
+	ssn, err := NewSSNFromString(s)
+	if err != nil && err != ErrCoordinationNumber {
+		return err
+	}
+	*n = *ssn
+	return nil
+}
+
+// MarshalText encodes the SSN in the standard YYYYMMDD-XXXX format. It lets an SSN be used as
+// a JSON object key, as in map[SSN]int. Keys are never masked, since masked keys could collide.
+func (n SSN) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText decodes an SSN from text, validated the same way as in NewSSNFromString
+// except that coordination numbers are accepted, as in UnmarshalJSON
+func (n *SSN) UnmarshalText(text []byte) error {
+	ssn, err := NewSSNFromString(string(text))
+	if err != nil && err != ErrCoordinationNumber {
+		return err
+	}
+	*n = *ssn
+	return nil
+}
+
+// MarshalJSON encodes the reserve number as a JSON string in the YYYYMMDD-LXXX format
+func (r ReserveNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON decodes a reserve number from a JSON string, validated like NewReserveNumberFromString
+func (r *ReserveNumber) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := NewReserveNumberFromString(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// UnmarshalJSONArray decodes a JSON array of SSNs, each encoded as accepted by UnmarshalJSON.
+// The first invalid or null element is reported as a *RowError with Row set to its index in the array.
+func UnmarshalJSONArray(data []byte) ([]*SSN, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	ssns := make([]*SSN, len(raw))
+	for i, r := range raw {
+		if string(r) == "null" {
+			return nil, &RowError{Row: i, Err: ErrFormat}
+		}
+		ssns[i] = new(SSN)
+		if err := ssns[i].UnmarshalJSON(r); err != nil {
+			return nil, &RowError{Row: i, Err: err}
+		}
+	}
+	return ssns, nil
+}
+
+// FromJSONObject makes a ssn type object from a JSON object with the date and the last four
+// digits in separate fields, {"birthDate":"1975-09-30","last":"1938"}, validated like NewSSNFromString
+func FromJSONObject(data []byte) (*SSN, error) {
+	var obj struct {
+		BirthDate string `json:"birthDate"`
+		Last      string `json:"last"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	var re = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	if !re.MatchString(obj.BirthDate) {
+		return nil, ErrFormat
+	}
+	tm, err := time.Parse("2006-01-02", obj.BirthDate)
+	if err != nil {
+		return nil, ErrDate
+	}
+	return NewSSNFromString(tm.Format("20060102") + "-" + obj.Last)
+}
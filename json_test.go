@@ -0,0 +1,222 @@
+package ssn
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	got, err := json.Marshal(pnr)
+	if err != nil {
+		t.Fatal("Could not marshal", err)
+	}
+	assert(string(got), `"19750930-1938"`, t)
+}
+
+func TestMarshalJSONMasked(t *testing.T) {
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	defer func() { MarshalMasked = false }()
+	for _, tc := range []struct {
+		masked bool
+		want   string
+	}{
+		{true, `"19750930-****"`},
+		{false, `"19750930-1938"`},
+	} {
+		MarshalMasked = tc.masked
+		got, err := json.Marshal(pnr)
+		if err != nil {
+			t.Fatal("Could not marshal", err)
+		}
+		assert(string(got), tc.want, t)
+	}
+}
+
+func TestMarshalVerbose(t *testing.T) {
+	defer func() { clock = time.Now }()
+	clock = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		ssn  SSN
+		want string
+	}{
+		{SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}, `{"ssn":"19750930-1938","birthDate":"1975-09-30","gender":"male","age":49}`},
+		{SSN{1, 9, 7, 2, 0, 5, 2, 5, 6, 6, 0, 0}, `{"ssn":"19720525-6600","birthDate":"1972-05-25","gender":"female","age":53}`},
+	}
+	for _, tc := range tests {
+		got, err := tc.ssn.MarshalVerbose()
+		if err != nil {
+			t.Fatal("Could not marshal", err)
+		}
+		assert(string(got), tc.want, t)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	want := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	tests := map[string]struct {
+		input string
+		err   error
+	}{
+		"String":                 {`"19750930-1938"`, nil},
+		"String without dash":    {`"197509301938"`, nil},
+		"Number":                 {`197509301938`, nil},
+		"Number too short":       {`7509301938`, ErrFormat},
+		"Number too long":        {`1197509301938`, ErrFormat},
+		"Number with fraction":   {`19750930193.8`, ErrFormat},
+		"Number with bad sum":    {`197509301939`, ErrChecksum},
+		"String with bad format": {`"1975-09-30"`, ErrFormat},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			var ssn SSN
+			err := json.Unmarshal([]byte(tc.input), &ssn)
+			if err != tc.err {
+				t.Errorf(util, "ERROR!", err, tc.err)
+			}
+			if err == nil && ssn != want {
+				t.Errorf(util, "SSN values!", ssn, want)
+			}
+		})
+	}
+
+	coordination := SSN{1, 9, 7, 5, 0, 3, 9, 0, 1, 9, 3, 1}
+	data, err := json.Marshal(coordination)
+	if err != nil {
+		t.Fatal("Could not marshal", err)
+	}
+	var got SSN
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal("Could not unmarshal coordination number", err)
+	}
+	assert(got, coordination, t)
+}
+
+func TestUnmarshalJSONInStruct(t *testing.T) {
+	var v struct {
+		A SSN `json:"a"`
+		B SSN `json:"b"`
+	}
+	err := json.Unmarshal([]byte(`{"a":"20110530-4933","b":200903016681}`), &v)
+	if err != nil {
+		t.Fatal("Could not unmarshal", err)
+	}
+	assert(v.A.String(), "20110530-4933", t)
+	assert(v.B.String(), "20090301-6681", t)
+}
+
+func TestJSONMapKeys(t *testing.T) {
+	m := map[SSN]int{
+		{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}: 1,
+		{2, 0, 1, 1, 0, 5, 3, 0, 4, 9, 3, 3}: 2,
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal("Could not marshal", err)
+	}
+	assert(string(data), `{"19750930-1938":1,"20110530-4933":2}`, t)
+
+	var got map[SSN]int
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal("Could not unmarshal", err)
+	}
+	assert(len(got), 2, t)
+	for k, v := range m {
+		assert(got[k], v, t)
+	}
+
+	coordination := map[SSN]int{{1, 9, 7, 5, 0, 3, 9, 0, 1, 9, 3, 1}: 1}
+	data, err = json.Marshal(coordination)
+	if err != nil {
+		t.Fatal("Could not marshal", err)
+	}
+	assert(string(data), `{"19750390-1931":1}`, t)
+	got = nil
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal("Could not unmarshal coordination number key", err)
+	}
+	assert(len(got), 1, t)
+	assert(got[SSN{1, 9, 7, 5, 0, 3, 9, 0, 1, 9, 3, 1}], 1, t)
+
+	err = json.Unmarshal([]byte(`{"19750930-1939":1}`), &got)
+	if !errors.Is(err, ErrChecksum) {
+		t.Errorf(util, "ERROR!", err, ErrChecksum)
+	}
+}
+
+func TestReserveNumberJSON(t *testing.T) {
+	r := ReserveNumber{Digits: SSN{1, 9, 7, 5, 0, 9, 3, 0, 0, 9, 3, 8}, Letter: 'T'}
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal("Could not marshal", err)
+	}
+	assert(string(data), `"19750930-T938"`, t)
+	var got ReserveNumber
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal("Could not unmarshal", err)
+	}
+	assert(got, r, t)
+
+	assert(json.Unmarshal([]byte(`"19750930-1938"`), &got), ErrFormat, t)
+	var ssn SSN
+	assert(json.Unmarshal(data, &ssn), ErrFormat, t)
+}
+
+func TestUnmarshalJSONArray(t *testing.T) {
+	ssns, err := UnmarshalJSONArray([]byte(`["19750930-1938", 200903016681, "20110530-4933"]`))
+	if err != nil {
+		t.Fatal("Could not unmarshal", err)
+	}
+	if len(ssns) != 3 {
+		t.Fatal("Want 3 SSNs, got", ssns)
+	}
+	assert(ssns[1].String(), "20090301-6681", t)
+
+	_, err = UnmarshalJSONArray([]byte(`["19750930-1938", "20090301-6684", "20110530-4933"]`))
+	var re *RowError
+	if !errors.As(err, &re) {
+		t.Fatal("Want RowError, got", err)
+	}
+	assert(re.Row, 1, t)
+	assert(re.Err, ErrChecksum, t)
+
+	_, err = UnmarshalJSONArray([]byte(`["19750930-1938", null]`))
+	if !errors.As(err, &re) {
+		t.Fatal("Want RowError, got", err)
+	}
+	assert(re.Row, 1, t)
+	assert(re.Err, ErrFormat, t)
+
+	if _, err = UnmarshalJSONArray([]byte(`{"a":"19750930-1938"}`)); err == nil {
+		t.Error("Want error for a JSON object")
+	}
+}
+
+func TestFromJSONObject(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		output string
+		err    error
+	}{
+		"Object":         {`{"birthDate":"1975-09-30","last":"1938"}`, "19750930-1938", nil},
+		"Bad checksum":   {`{"birthDate":"1975-09-30","last":"1939"}`, "19750930-1939", ErrChecksum},
+		"Bad date":       {`{"birthDate":"1975-02-30","last":"1938"}`, "", ErrDate},
+		"Compact date":   {`{"birthDate":"19750930","last":"1938"}`, "", ErrFormat},
+		"Short last":     {`{"birthDate":"1975-09-30","last":"938"}`, "", ErrFormat},
+		"Missing fields": {`{}`, "", ErrFormat},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			ssn, err := FromJSONObject([]byte(tc.input))
+			assert(err, tc.err, t)
+			if tc.output != "" {
+				assert(ssn.String(), tc.output, t)
+			}
+		})
+	}
+	if _, err := FromJSONObject([]byte(`{"birthDate":`)); err == nil {
+		t.Error("Want error for malformed JSON")
+	}
+}
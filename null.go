@@ -0,0 +1,68 @@
+package ssn
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// NullSSN represents an SSN that may be null, in the same way as sql.NullString.
+// It implements the sql.Scanner, driver.Valuer and JSON interfaces.
+type NullSSN struct {
+	SSN   SSN
+	Valid bool // Valid is true if SSN is not NULL
+}
+
+// Scan implements the sql.Scanner interface. Strings and byte slices are parsed like
+// NewSSNFromString, but coordination numbers are accepted, and integers are read as the 12 digit number.
+func (n *NullSSN) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		n.SSN, n.Valid = SSN{}, false
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	default:
+		return fmt.Errorf("Cannot scan %T into NullSSN", value)
+	}
+	ssn, err := NewSSNFromString(s)
+	if err != nil && err != ErrCoordinationNumber {
+		return err
+	}
+	n.SSN, n.Valid = *ssn, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface, a valid SSN is stored as YYYYMMDD-XXXX
+func (n NullSSN) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.SSN.String(), nil
+}
+
+// MarshalJSON encodes a valid SSN like SSN.MarshalJSON and an invalid one as null
+func (n NullSSN) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.SSN.MarshalJSON()
+}
+
+// UnmarshalJSON decodes null as an invalid NullSSN and anything else like SSN.UnmarshalJSON
+func (n *NullSSN) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.SSN, n.Valid = SSN{}, false
+		return nil
+	}
+	if err := n.SSN.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,114 @@
+package ssn
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+// echoDriver is a fake SQL driver whose queries return their single argument as the only row
+type echoDriver struct{}
+
+func (echoDriver) Open(string) (driver.Conn, error) { return echoConn{}, nil }
+
+type echoConn struct{}
+
+func (echoConn) Prepare(string) (driver.Stmt, error) { return echoStmt{}, nil }
+func (echoConn) Close() error                        { return nil }
+func (echoConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type echoStmt struct{}
+
+func (echoStmt) Close() error  { return nil }
+func (echoStmt) NumInput() int { return 1 }
+func (echoStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{value: args[0]}, nil
+}
+
+type echoRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (*echoRows) Columns() []string { return []string{"ssn"} }
+func (*echoRows) Close() error      { return nil }
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func init() {
+	sql.Register("ssnecho", echoDriver{})
+}
+
+func TestNullSSNSQL(t *testing.T) {
+	db, err := sql.Open("ssnecho", "")
+	if err != nil {
+		t.Fatal("Could not open fake database", err)
+	}
+	defer db.Close()
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	coordination := SSN{1, 9, 7, 5, 0, 3, 9, 0, 1, 9, 3, 1}
+	tests := map[string]struct {
+		in   interface{}
+		want NullSSN
+		err  bool
+	}{
+		"null":         {NullSSN{}, NullSSN{}, false},
+		"present":      {NullSSN{pnr, true}, NullSSN{pnr, true}, false},
+		"coordination": {NullSSN{coordination, true}, NullSSN{coordination, true}, false},
+		"string":       {"19750930-1938", NullSSN{pnr, true}, false},
+		"integer":      {int64(197509301938), NullSSN{pnr, true}, false},
+		"bad integer":  {int64(7509301938), NullSSN{}, true},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			var got NullSSN
+			err := db.QueryRow("SELECT ?", tc.in).Scan(&got)
+			if (err != nil) != tc.err {
+				t.Fatal("Unexpected error", err)
+			}
+			if err == nil {
+				assert(got, tc.want, t)
+			}
+		})
+	}
+}
+
+func TestNullSSNJSON(t *testing.T) {
+	type record struct {
+		SSN NullSSN `json:"ssn"`
+	}
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	tests := map[string]struct {
+		in   record
+		json string
+	}{
+		"null":    {record{NullSSN{}}, `{"ssn":null}`},
+		"present": {record{NullSSN{pnr, true}}, `{"ssn":"19750930-1938"}`},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			data, err := json.Marshal(tc.in)
+			if err != nil {
+				t.Fatal("Could not marshal", err)
+			}
+			assert(string(data), tc.json, t)
+			out := record{NullSSN{SSN{1}, true}}
+			if err := json.Unmarshal(data, &out); err != nil {
+				t.Fatal("Could not unmarshal", err)
+			}
+			assert(out, tc.in, t)
+		})
+	}
+}
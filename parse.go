@@ -0,0 +1,177 @@
+package ssn
+
+import "unicode"
+
+// Kind is the kind of number an SSN is, as returned by SSN.Kind, ReserveNumber.Kind and ParseAny
+type Kind string
+
+// Kinds of SSN
+const (
+	// KindPersonnummer is an ordinary personal identity number
+	KindPersonnummer Kind = "personnummer"
+	// KindCoordination is a coordination number, samordningsnummer, with 60 added to the day
+	KindCoordination Kind = "samordningsnummer"
+	// KindReserve is a reserve number with a letter in place of a birth number digit
+	KindReserve Kind = "reserve"
+)
+
+// ParseMode configures the leniency of ParseWithMode. The zero value parses as strictly
+// as NewSSNFromString, except that it also accepts "+" as separator.
+type ParseMode struct {
+	// AllowedSeparators replaces the separators accepted between date and birth number,
+	// nil means "-" and "+"
+	AllowedSeparators []rune
+	// AllowMarker accepts a single letter before or after the number,
+	// which some healthcare systems use to tag coordination or reserve numbers
+	AllowMarker bool
+	// AllowReserve accepts reserve numbers, where a letter takes the place of the first
+	// birth number digit, as in YYYYMMDD-T123. The SSN then holds 0 in place of the letter,
+	// which is reported in Meta. The check digit is kept as written, since the Luhn
+	// algorithm has no value for a letter to validate it against.
+	AllowReserve bool
+	// AutoChecksum accepts a placeholder "_", "x" or "X" in place of the checksum digit
+	// and fills in the correct checksum. A trailing "x" is then never taken for a marker.
+	AutoChecksum bool
+}
+
+// Meta holds what ParseWithMode found in the input besides the SSN itself
+type Meta struct {
+	// Marker is the stripped marker letter in upper case, or 0 if there was none
+	Marker rune
+	// ReserveLetter is the upper case letter of a reserve number, or 0 if the input was not one
+	ReserveLetter rune
+	// ReserveIndex is the position of ReserveLetter in the SSN
+	ReserveIndex int
+}
+
+// defaultSeparators are the separators accepted when ParseMode.AllowedSeparators is nil
+var defaultSeparators = []rune{'-', '+'}
+
+// reserveIndex is where reserve numbers have their letter
+const reserveIndex = 8
+
+func isChecksumPlaceholder(b byte) bool {
+	return b == '_' || b == 'x' || b == 'X'
+}
+
+func isASCIILetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// ParseWithMode makes a ssn type object from a string like NewSSNFromString,
+// but accepts the deviations enabled in mode and reports them in Meta
+func ParseWithMode(s string, mode ParseMode) (*SSN, Meta, error) {
+	var meta Meta
+	if mode.AllowMarker && len(s) > 0 {
+		if isASCIILetter(s[0]) {
+			meta.Marker = unicode.ToUpper(rune(s[0]))
+			s = s[1:]
+		} else if last := s[len(s)-1]; isASCIILetter(last) && !(mode.AutoChecksum && isChecksumPlaceholder(last)) {
+			meta.Marker = unicode.ToUpper(rune(s[len(s)-1]))
+			s = s[:len(s)-1]
+		}
+	}
+	if r := []rune(s); len(r) == 13 {
+		seps := mode.AllowedSeparators
+		if seps == nil {
+			seps = defaultSeparators
+		}
+		if !containsRune(seps, r[8]) {
+			return nil, meta, ErrFormat
+		}
+		s = string(r[:8]) + "-" + string(r[9:])
+	}
+	var placeholder bool
+	if mode.AutoChecksum && (len(s) == 12 || len(s) == 13) && isChecksumPlaceholder(s[len(s)-1]) {
+		s, placeholder = s[:len(s)-1]+"0", true
+	}
+	if mode.AllowReserve {
+		i := reserveIndex
+		if len(s) == 13 {
+			i++
+		}
+		if len(s) > i && isASCIILetter(s[i]) {
+			return parseReserve(s, i, meta)
+		}
+	}
+	ssn, err := NewSSNFromString(s)
+	if placeholder && err == ErrChecksum {
+		ssn[11] = GetChecksum(*ssn)
+		err = ssn.Validate()
+	}
+	return ssn, meta, err
+}
+
+// ReserveNumber is a reserve number such as 19750930-T938, where a letter takes the place
+// of the first birth number digit. It is kept apart from SSN, which only ever holds digits.
+type ReserveNumber struct {
+	// Digits holds the date and the other digits, with 0 in place of the letter
+	Digits SSN
+	// Letter is the upper case letter
+	Letter rune
+}
+
+// NewReserveNumberFromString makes a ReserveNumber from a string like 19750930-T938 or 19750930T938.
+// ErrFormat is returned if there is no letter in place of the first birth number digit.
+func NewReserveNumberFromString(s string) (ReserveNumber, error) {
+	ssn, meta, err := ParseWithMode(s, ParseMode{AllowedSeparators: []rune{'-'}, AllowReserve: true})
+	if err != nil {
+		return ReserveNumber{}, err
+	}
+	if meta.ReserveLetter == 0 {
+		return ReserveNumber{}, ErrFormat
+	}
+	return ReserveNumber{Digits: *ssn, Letter: meta.ReserveLetter}, nil
+}
+
+// String returns the reserve number in YYYYMMDD-LXXX format
+func (r ReserveNumber) String() string {
+	b := []byte(r.Digits.String())
+	b[reserveIndex+1] = byte(r.Letter)
+	return string(b)
+}
+
+// Kind returns KindReserve, so that reserve numbers report their kind like an SSN does
+func (r ReserveNumber) Kind() Kind {
+	return KindReserve
+}
+
+// ParseAny makes a ssn type object from a personnummer, coordination number or reserve number
+// and returns which kind it was. Coordination numbers are not reported as ErrCoordinationNumber,
+// other errors are as from ParseWithMode with AllowReserve set.
+// The SSN of a reserve number holds 0 in place of the letter, as with ParseMode.AllowReserve.
+func ParseAny(s string) (*SSN, Kind, error) {
+	ssn, meta, err := ParseWithMode(s, ParseMode{AllowReserve: true})
+	if err == ErrCoordinationNumber {
+		err = nil
+	}
+	if ssn == nil {
+		return nil, "", err
+	}
+	if meta.ReserveLetter != 0 {
+		return ssn, KindReserve, err
+	}
+	return ssn, ssn.Kind(), err
+}
+
+// parseReserve parses s with the reserve letter at index i in place of a digit,
+// leaving 0 in the SSN and the letter in meta
+func parseReserve(s string, i int, meta Meta) (*SSN, Meta, error) {
+	letter := unicode.ToUpper(rune(s[i]))
+	// With 0 in place of the letter the check digit cannot match, so ErrChecksum is expected
+	ssn, err := NewSSNFromString(s[:i] + "0" + s[i+1:])
+	if err != nil && err != ErrChecksum {
+		return nil, meta, err
+	}
+	meta.ReserveLetter, meta.ReserveIndex = letter, reserveIndex
+	return ssn, meta, nil
+}
+
+func containsRune(rs []rune, r rune) bool {
+	for _, x := range rs {
+		if x == r {
+			return true
+		}
+	}
+	return false
+}
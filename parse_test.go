@@ -0,0 +1,204 @@
+package ssn
+
+import "testing"
+
+func TestParseWithModeMarker(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		mode   ParseMode
+		marker rune
+		err    error
+	}{
+		"Unmarked strict":          {"19750930-1938", ParseMode{}, 0, nil},
+		"Marked strict":            {"S19750930-1938", ParseMode{}, 0, ErrFormat},
+		"Unmarked lenient":         {"19750930-1938", ParseMode{AllowMarker: true}, 0, nil},
+		"Leading marker":           {"S19750930-1938", ParseMode{AllowMarker: true}, 'S', nil},
+		"Lowercase leading marker": {"s19750930-1938", ParseMode{AllowMarker: true}, 'S', nil},
+		"Trailing marker":          {"197509301938R", ParseMode{AllowMarker: true}, 'R', nil},
+		"Marker on both sides":     {"S19750930-1938R", ParseMode{AllowMarker: true}, 'S', ErrFormat},
+		"Non letter marker":        {"#19750930-1938", ParseMode{AllowMarker: true}, 0, ErrFormat},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			ssn, meta, err := ParseWithMode(tc.input, tc.mode)
+			assert(err, tc.err, t)
+			assert(meta.Marker, tc.marker, t)
+			if err == nil {
+				assert(ssn.String(), "19750930-1938", t)
+			}
+		})
+	}
+}
+
+func TestParseWithModeReserve(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		mode   ParseMode
+		output string
+		letter rune
+		err    error
+	}{
+		"Normal strict":             {"19750930-1938", ParseMode{}, "19750930-1938", 0, nil},
+		"Reserve strict":            {"19750930-T938", ParseMode{}, "", 0, ErrFormat},
+		"Normal lenient":            {"19750930-1938", ParseMode{AllowReserve: true}, "19750930-1938", 0, nil},
+		"Normal lenient bad sum":    {"19750930-1939", ParseMode{AllowReserve: true}, "19750930-1939", 0, ErrChecksum},
+		"Reserve":                   {"19750930-T938", ParseMode{AllowReserve: true}, "19750930-0938", 'T', nil},
+		"Reserve without separator": {"19750930t938", ParseMode{AllowReserve: true}, "19750930-0938", 'T', nil},
+		"Reserve with bad date":     {"19751330-T938", ParseMode{AllowReserve: true}, "", 0, ErrDate},
+		"Reserve with letters":      {"19750930-TX38", ParseMode{AllowReserve: true}, "", 0, ErrFormat},
+		"Reserve marked":            {"19750930-T938M", ParseMode{AllowReserve: true, AllowMarker: true}, "19750930-0938", 'T', nil},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			ssn, meta, err := ParseWithMode(tc.input, tc.mode)
+			assert(err, tc.err, t)
+			assert(meta.ReserveLetter, tc.letter, t)
+			if tc.letter != 0 {
+				assert(meta.ReserveIndex, 8, t)
+			}
+			if tc.output != "" {
+				assert(ssn.String(), tc.output, t)
+			}
+		})
+	}
+}
+
+func TestParseWithModeAutoChecksum(t *testing.T) {
+	auto := ParseMode{AutoChecksum: true}
+	tests := map[string]struct {
+		input  string
+		mode   ParseMode
+		output string
+		err    error
+	}{
+		"Underscore":           {"19750930-193_", auto, "19750930-1938", nil},
+		"Lowercase x":          {"19750930193x", auto, "19750930-1938", nil},
+		"X without dash":       {"20110530493X", auto, "20110530-4933", nil},
+		"Plus separator":       {"19750930+193x", auto, "19750930-1938", nil},
+		"Another date":         {"20090301-668_", auto, "20090301-6681", nil},
+		"Digit still checked":  {"19750930-1939", auto, "19750930-1939", ErrChecksum},
+		"Bad date":             {"19750231-193_", auto, "", ErrDate},
+		"Coordination number":  {"19750390-193_", auto, "19750390-1931", ErrCoordinationNumber},
+		"Placeholder strict":   {"19750930-193_", ParseMode{}, "", ErrFormat},
+		"With trailing marker": {"19750930-193xR", ParseMode{AutoChecksum: true, AllowMarker: true}, "19750930-1938", nil},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			ssn, _, err := ParseWithMode(tc.input, tc.mode)
+			assert(err, tc.err, t)
+			if tc.output != "" {
+				assert(ssn.String(), tc.output, t)
+			}
+		})
+	}
+}
+
+func TestReserveNumber(t *testing.T) {
+	r, err := NewReserveNumberFromString("19750930-t938")
+	if err != nil {
+		t.Fatal("Could not parse reserve number", err)
+	}
+	assert(r.Letter, 'T', t)
+	assert(r.Digits, SSN{1, 9, 7, 5, 0, 9, 3, 0, 0, 9, 3, 8}, t)
+	assert(r.String(), "19750930-T938", t)
+	for _, d := range r.Digits {
+		if d < 0 || d > 9 {
+			t.Error("Non digit in reserve number", r.Digits)
+		}
+	}
+	back, err := FromID64(r.Digits.ID64())
+	assert(err, nil, t)
+	assert(back, r.Digits, t)
+
+	tests := map[string]struct {
+		input string
+		err   error
+	}{
+		"Without separator": {"19750930T938", nil},
+		"Personnummer":      {"19750930-1938", ErrFormat},
+		"Plus separator":    {"19750930+T938", ErrFormat},
+		"Bad date":          {"19751330-T938", ErrDate},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			_, err := NewReserveNumberFromString(tc.input)
+			assert(err, tc.err, t)
+		})
+	}
+}
+
+func TestKind(t *testing.T) {
+	tests := []struct {
+		name   string
+		number interface{ Kind() Kind }
+		want   Kind
+	}{
+		{"personnummer", SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}, KindPersonnummer},
+		{"samordningsnummer", SSN{1, 9, 7, 5, 0, 9, 9, 0, 1, 9, 3, 2}, KindCoordination},
+		{"samordningsnummer first day", SSN{1, 9, 7, 5, 0, 9, 6, 1, 1, 9, 3, 2}, KindCoordination},
+		{"reserve", ReserveNumber{Digits: SSN{1, 9, 7, 5, 0, 9, 3, 0, 0, 9, 3, 8}, Letter: 'T'}, KindReserve},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert(tt.number.Kind(), tt.want, t)
+		})
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		output string
+		kind   Kind
+		err    error
+	}{
+		"Personnummer":         {"19750930-1938", "19750930-1938", KindPersonnummer, nil},
+		"Coordination number":  {"19750390-1931", "19750390-1931", KindCoordination, nil},
+		"Reserve number":       {"19750930-T938", "19750930-0938", KindReserve, nil},
+		"Bad checksum":         {"19750930-1939", "19750930-1939", KindPersonnummer, ErrChecksum},
+		"Invalid date":         {"19751330-1938", "", "", ErrDate},
+		"Invalid format":       {"1975-09-30", "", "", ErrFormat},
+		"Bad coordination sum": {"19750390-1932", "19750390-1932", KindCoordination, ErrChecksum},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			ssn, kind, err := ParseAny(tc.input)
+			assert(err, tc.err, t)
+			assert(kind, tc.kind, t)
+			if tc.output != "" {
+				assert(ssn.String(), tc.output, t)
+			}
+		})
+	}
+}
+
+func TestParseWithModeSeparators(t *testing.T) {
+	custom := ParseMode{AllowedSeparators: []rune{'/', '.', '–'}}
+	tests := map[string]struct {
+		input string
+		mode  ParseMode
+		err   error
+	}{
+		"Default dash":         {"19750930-1938", ParseMode{}, nil},
+		"Default plus":         {"19750930+1938", ParseMode{}, nil},
+		"Default none":         {"197509301938", ParseMode{}, nil},
+		"Default slash":        {"19750930/1938", ParseMode{}, ErrFormat},
+		"Custom slash":         {"19750930/1938", custom, nil},
+		"Custom dot":           {"19750930.1938", custom, nil},
+		"Custom en dash":       {"19750930–1938", custom, nil},
+		"Custom none":          {"197509301938", custom, nil},
+		"Custom dash":          {"19750930-1938", custom, ErrFormat},
+		"Custom reserve slash": {"19750930/T938", ParseMode{AllowedSeparators: []rune{'/'}, AllowReserve: true}, nil},
+		"Custom marker slash":  {"19750930/1938X", ParseMode{AllowedSeparators: []rune{'/'}, AllowMarker: true}, nil},
+		"Separator too early":  {"1975093/01938", custom, ErrFormat},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			ssn, _, err := ParseWithMode(tc.input, tc.mode)
+			assert(err, tc.err, t)
+			if err == nil && ssn.Format(true, false)[:8] != "19750930" {
+				t.Error("Wrong date", ssn)
+			}
+		})
+	}
+}
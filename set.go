@@ -0,0 +1,72 @@
+package ssn
+
+import "errors"
+
+// ErrExhausted is returned when no SSN outside a blocklist was found in time
+var ErrExhausted = errors.New("Could not generate an SSN outside the blocklist")
+
+// avoidTries is how many SSNs NewRandomSSNAvoiding generates before giving up
+var avoidTries = 1000
+
+// SSNSet is a set of SSNs for membership checks such as blocklists
+type SSNSet map[SSN]struct{}
+
+// NewSSNSetFromStrings parses the strings like NewSSNFromString into a set.
+// Strings that fail are left out and reported as *RowError with Row being their index.
+func NewSSNSetFromStrings(ss []string) (SSNSet, []error) {
+	set := make(SSNSet, len(ss))
+	var errs []error
+	for i, s := range ss {
+		ssn, err := NewSSNFromString(s)
+		if err != nil {
+			errs = append(errs, &RowError{Row: i, Err: err})
+			continue
+		}
+		set.Add(*ssn)
+	}
+	return set, errs
+}
+
+// Add adds n to the set
+func (s SSNSet) Add(n SSN) {
+	s[n] = struct{}{}
+}
+
+// Contains reports whether n is in the set
+func (s SSNSet) Contains(n SSN) bool {
+	_, ok := s[n]
+	return ok
+}
+
+// Remove removes n from the set
+func (s SSNSet) Remove(n SSN) {
+	delete(s, n)
+}
+
+// NewRandomSSNAvoiding will return a SSN like NewRandomSSN that is not in block.
+// ErrExhausted is returned if none is found within a fixed number of tries.
+func NewRandomSSNAvoiding(block SSNSet) (*SSN, error) {
+	for i := 0; i < avoidTries; i++ {
+		if ssn := NewRandomSSN(); !block.Contains(*ssn) {
+			return ssn, nil
+		}
+	}
+	return nil, ErrExhausted
+}
+
+// FirstDuplicate returns the earliest SSN in ssns that appears more than once, and whether there was one.
+// Nil entries are skipped.
+func FirstDuplicate(ssns []*SSN) (*SSN, bool) {
+	counts := make(map[SSN]int, len(ssns))
+	for _, ssn := range ssns {
+		if ssn != nil {
+			counts[*ssn]++
+		}
+	}
+	for _, ssn := range ssns {
+		if ssn != nil && counts[*ssn] > 1 {
+			return ssn, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,92 @@
+package ssn
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSSNSet(t *testing.T) {
+	pnr := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	other := SSN{2, 0, 1, 1, 0, 5, 3, 0, 4, 9, 3, 3}
+	set := make(SSNSet)
+	assert(set.Contains(pnr), false, t)
+	set.Add(pnr)
+	set.Add(pnr)
+	assert(set.Contains(pnr), true, t)
+	assert(set.Contains(other), false, t)
+	assert(len(set), 1, t)
+	set.Remove(pnr)
+	assert(set.Contains(pnr), false, t)
+}
+
+func TestNewSSNSetFromStrings(t *testing.T) {
+	set, errs := NewSSNSetFromStrings([]string{"19750930-1938", "20090301-6684", "201105304933"})
+	assert(len(set), 2, t)
+	assert(set.Contains(SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}), true, t)
+	assert(set.Contains(SSN{2, 0, 1, 1, 0, 5, 3, 0, 4, 9, 3, 3}), true, t)
+	if len(errs) != 1 {
+		t.Fatal("Want 1 error, got", errs)
+	}
+	var re *RowError
+	if !errors.As(errs[0], &re) || re.Row != 1 || re.Err != ErrChecksum {
+		t.Errorf(util, "error", errs[0], ErrChecksum)
+	}
+}
+
+func TestNewRandomSSNAvoiding(t *testing.T) {
+	defer func() { clock = time.Now }()
+	clock = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	defer Seed(time.Now().UnixNano())
+	Seed(42)
+	block := make(SSNSet)
+	for i := 0; i < 3; i++ {
+		block.Add(*NewRandomSSN())
+	}
+	Seed(42)
+	ssn, err := NewRandomSSNAvoiding(block)
+	if err != nil {
+		t.Fatal("Could not generate SSN", err)
+	}
+	assert(block.Contains(*ssn), false, t)
+	assert(ssn.Validate(), nil, t)
+
+	Seed(42)
+	for i := 0; i < avoidTries; i++ {
+		block.Add(*NewRandomSSN())
+	}
+	Seed(42)
+	ssn, err = NewRandomSSNAvoiding(block)
+	assert(err, ErrExhausted, t)
+	if ssn != nil {
+		t.Error("Want nil SSN, got", ssn)
+	}
+}
+
+func TestFirstDuplicate(t *testing.T) {
+	a := SSN{1, 9, 7, 5, 0, 9, 3, 0, 1, 9, 3, 8}
+	b := SSN{2, 0, 1, 1, 0, 5, 3, 0, 4, 9, 3, 3}
+	c := SSN{2, 0, 0, 9, 0, 3, 0, 1, 6, 6, 8, 1}
+	copyOf := func(n SSN) *SSN { return &n }
+	tests := map[string]struct {
+		ssns []*SSN
+		want *SSN
+	}{
+		"None":        {[]*SSN{&a, &b, &c}, nil},
+		"Empty":       {nil, nil},
+		"Adjacent":    {[]*SSN{&a, &b, copyOf(b), &c}, &b},
+		"Earliest":    {[]*SSN{&a, &b, copyOf(b), copyOf(a)}, &a},
+		"With nil":    {[]*SSN{nil, &c, nil, copyOf(c)}, &c},
+		"Only nils":   {[]*SSN{nil, nil}, nil},
+		"Same values": {[]*SSN{copyOf(c), copyOf(c)}, &c},
+	}
+	for label, tc := range tests {
+		t.Run(label, func(t *testing.T) {
+			got, ok := FirstDuplicate(tc.ssns)
+			assert(ok, tc.want != nil, t)
+			if tc.want != nil && ok {
+				assert(*got, *tc.want, t)
+			}
+		})
+	}
+}
@@ -4,29 +4,60 @@ package ssn
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
+// rng is the package's own random generator so the global math/rand state is left to the caller
+var rng = rand.New(&lockedSource{src: rand.NewSource(time.Now().UnixNano())})
+
+// lockedSource makes a rand.Source safe for concurrent use
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
 }
 
-// SSN is a representation of a 12 digit swedish social security number
+// Seed reseeds the generator used for random SSNs, e.g. to get reproducible test data.
+// Without it the generator is seeded from the current time.
+func Seed(seed int64) {
+	rng.Seed(seed)
+}
+
+// clock is used where no reference time is passed in, and replaced in tests
+var clock = time.Now
+
+// SSN is a representation of a 12 digit swedish social security number and only holds digits.
+// Reserve numbers, which have a letter in place of a digit, are held by ReserveNumber.
+// Coordination numbers keep the day of birth plus 60 as day digits, so String and Format show
+// it as written, while Date and Time subtract the 60 to give the actual date of birth.
 type SSN [12]int
 
 // GetRandomTime gets a random time
 // Durations count backwards from Now
 func GetRandomTime(from, to time.Duration) time.Time {
-	t1 := time.Now()
+	t1 := clock()
 	diff := from - to
 	if diff <= 0 {
 		return t1.Add(-from)
 	}
-	randomDiff := time.Duration(rand.Int63n(int64(diff)))
+	randomDiff := time.Duration(rng.Int63n(int64(diff)))
 	t2 := t1.Add(-randomDiff - to)
 	return t2
 }
@@ -48,24 +79,58 @@ var (
 	ErrFormat   = errors.New("Input does not match YYYYMMDD-XXXX or YYYYMMDDXXXX")
 	ErrDate     = errors.New("Could not parse date")
 	ErrChecksum = errors.New("Checksum is incorrect")
+	ErrCentury  = errors.New("Could not infer a plausible century")
+
+	ErrBirthNumber = errors.New("Birth number must be within 0-999")
+	ErrGender      = errors.New("Gender must be Female or Male")
+	ErrDigits      = errors.New("Input must consist of digits only")
+	ErrArgument    = errors.New("Argument out of range")
+	ErrYear        = errors.New("Birth year is implausibly early")
+
+	// ErrCoordinationNumber is returned for coordination numbers (samordningsnummer),
+	// whose day is the day of birth plus 60, until they are fully supported
+	ErrCoordinationNumber = errors.New("Input is a coordination number")
+
+	// ErrEmpty is returned for empty or whitespace-only input, errors.Is(ErrEmpty, ErrFormat) holds
+	ErrEmpty = fmt.Errorf("Input is empty: %w", ErrFormat)
+	// ErrDoubledCentury is returned for input like 1919750930-1938 with the century typed twice,
+	// errors.Is(ErrDoubledCentury, ErrFormat) holds
+	ErrDoubledCentury = fmt.Errorf("Century is repeated: %w", ErrFormat)
 )
 
+// MaxPlausibleAge is the age no person is assumed to reach. Inferring the century of a short
+// form SSN never picks a century that would make the person this old or older, and
+// ValidatePlausible rejects such people. As the separator tells whether someone is 100 or
+// older, at most one century fits, so lowering it can reject a short form but never change
+// the century inferred for it.
+var MaxPlausibleAge = 125
+
 // NewSSNFromString makes a ssn type object from a string and at the same time validates that string
-// to format, date, checksum and will send errors accordingly
+// to format, date, checksum and will send errors accordingly.
+// Coordination numbers are returned along with ErrCoordinationNumber, or ErrChecksum
+// if the checksum is incorrect.
 func NewSSNFromString(s string) (*SSN, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, ErrEmpty
+	}
 	var re = regexp.MustCompile(`^[0-9]{8}-?[0-9]{4}$`)
 	ok := re.MatchString(s)
 	if !ok {
+		var doubled = regexp.MustCompile(`^[0-9]{10}-?[0-9]{4}$`)
+		if doubled.MatchString(s) && s[0:2] == s[2:4] {
+			return nil, ErrDoubledCentury
+		}
 		return nil, ErrFormat
 	}
 	if len(s) == 12 {
 		s = s[0:8] + "-" + s[8:12]
 	}
+	var ssn SSN
 	tm, err := time.Parse("20060102", s[0:8])
-	if err != nil {
+	coordination := err != nil && isCoordinationDate(s[0:8])
+	if err != nil && !coordination {
 		return nil, ErrDate
 	}
-	var ssn SSN
 	ssn.SetDate(tm)
 	for i := 8; i < 12; i++ {
 		ssn[i], err = strconv.Atoi(string(s[i+1]))
@@ -73,25 +138,323 @@ func NewSSNFromString(s string) (*SSN, error) {
 			panic("Error parsing digit, probably got letter")
 		}
 	}
+	if coordination {
+		for i := 0; i < 8; i++ {
+			ssn[i] = int(s[i] - '0')
+		}
+		if GetChecksum(ssn) != ssn[11] {
+			return &ssn, ErrChecksum
+		}
+		return &ssn, ErrCoordinationNumber
+	}
 	if GetChecksum(ssn) != ssn[11] {
 		return &ssn, ErrChecksum
 	}
 	return &ssn, nil
 }
 
+// isCoordinationDate reports whether the YYYYMMDD string date has a day of 61-91
+// that is a valid date once 60 is subtracted
+func isCoordinationDate(date string) bool {
+	day, err := strconv.Atoi(date[6:8])
+	if err != nil || day <= 60 || day > 91 {
+		return false
+	}
+	_, err = time.Parse("20060102", fmt.Sprintf("%s%02d", date[0:6], day-60))
+	return err == nil
+}
+
+// parseBirthDate parses a YYYYMMDD date, taking 60 off the day of a coordination date
+func parseBirthDate(date string) (time.Time, error) {
+	if isCoordinationDate(date) {
+		day, _ := strconv.Atoi(date[6:8])
+		date = fmt.Sprintf("%s%02d", date[0:6], day-60)
+	}
+	return time.Parse("20060102", date)
+}
+
+// Validate checks a SSN value the same way NewSSNFromString checks a string
+// and returns ErrFormat, ErrDate, ErrCoordinationNumber or ErrChecksum accordingly
+func (n SSN) Validate() error {
+	for _, d := range n {
+		if d < 0 || d > 9 {
+			return ErrFormat
+		}
+	}
+	if _, err := time.Parse("20060102", intSliceToString(n[0:8])); err != nil {
+		if !isCoordinationDate(intSliceToString(n[0:8])) {
+			return ErrDate
+		}
+		if GetChecksum(n) != n[11] {
+			return ErrChecksum
+		}
+		return ErrCoordinationNumber
+	}
+	if GetChecksum(n) != n[11] {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// MinYear is the earliest birth year ValidatePlausible accepts
+var MinYear = 1860
+
+// ValidatePlausible checks the SSN like Validate and also returns ErrYear if the birth year
+// is before MinYear, as OCR errors producing years like 0018 would be, or if the person
+// would be MaxPlausibleAge or older today
+func (n SSN) ValidatePlausible() error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	if year, _, _ := n.Date(); year < MinYear {
+		return ErrYear
+	}
+	if n.AgeYears(clock()) >= MaxPlausibleAge {
+		return ErrYear
+	}
+	return nil
+}
+
+// StringValid returns SSN in standard YYYYMMDD-XXXX format and whether it passes Validate
+func (n SSN) StringValid() (string, bool) {
+	return n.String(), n.Validate() == nil
+}
+
+// NewSSNFromStrings makes a ssn type object from the date YYYYMMDD and the last digits XXXX
+// given separately, as in spreadsheets with split columns, validated like NewSSNFromString
+func NewSSNFromStrings(dateStr, lastStr string) (*SSN, error) {
+	var reDate = regexp.MustCompile(`^[0-9]{8}$`)
+	var reLast = regexp.MustCompile(`^[0-9]{4}$`)
+	if !reDate.MatchString(dateStr) || !reLast.MatchString(lastStr) {
+		return nil, ErrFormat
+	}
+	return NewSSNFromString(dateStr + "-" + lastStr)
+}
+
+// ParseAndFix makes a ssn type object from a string like NewSSNFromString, but replaces an
+// incorrect checksum with the correct one and reports that in corrected.
+// Errors are only returned for format and date problems, and ErrCoordinationNumber as from
+// NewSSNFromString, with the corrected value, for coordination numbers.
+func ParseAndFix(s string) (ssn *SSN, corrected bool, err error) {
+	ssn, err = NewSSNFromString(s)
+	if err == ErrChecksum {
+		ssn[11] = GetChecksum(*ssn)
+		return ssn, true, ssn.Validate()
+	}
+	return ssn, false, err
+}
+
+// ParseOK parses s like NewSSNFromString and reports in ok whether it was valid,
+// for callers who do not need to know what was wrong. The SSN is the zero value if not.
+func ParseOK(s string) (ssn SSN, ok bool) {
+	n, err := NewSSNFromString(s)
+	if err != nil {
+		return SSN{}, false
+	}
+	return *n, true
+}
+
+// RepairOneMissing tries the digits 0-9 for an unreadable digit, e.g. in OCR output, and returns
+// all resulting valid SSNs. s is in the YYYYMMDD-XXXX or YYYYMMDDXXXX form with any placeholder
+// at the unreadable digit, whose position among the 12 digits is missingIndex.
+// A missing birth number or checksum digit has exactly one candidate, a missing century digit several.
+func RepairOneMissing(s string, missingIndex int) ([]*SSN, error) {
+	if len(s) != 12 && len(s) != 13 {
+		return nil, ErrFormat
+	}
+	if missingIndex < 0 || missingIndex > 11 {
+		return nil, ErrArgument
+	}
+	i := missingIndex
+	if len(s) == 13 && i >= 8 {
+		i++
+	}
+	var candidates []*SSN
+	for d := '0'; d <= '9'; d++ {
+		ssn, err := NewSSNFromString(s[:i] + string(d) + s[i+1:])
+		switch err {
+		case nil:
+			candidates = append(candidates, ssn)
+		case ErrFormat:
+			return nil, err
+		}
+	}
+	return candidates, nil
+}
+
+// NewSSNFromShortString makes a ssn type object from a 10 digit YYMMDD-XXXX, YYMMDD+XXXX or YYMMDDXXXX string.
+// The century is inferred relative to now: a "+" separator means the person is 100 or older,
+// otherwise younger than 100. ErrCentury is returned when no century gives a date that is
+// neither in the future nor implausibly far back. Coordination numbers are returned along with
+// ErrCoordinationNumber, as from NewSSNFromString.
+func NewSSNFromShortString(s string, now time.Time) (*SSN, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, ErrEmpty
+	}
+	var re = regexp.MustCompile(`^[0-9]{6}[-+]?[0-9]{4}$`)
+	if !re.MatchString(s) {
+		return nil, ErrFormat
+	}
+	centenarian := s[6] == '+'
+	date, last := s[0:6], s[len(s)-4:]
+	validDate := false
+	for c := now.Year() / 100; c >= (now.Year()-MaxPlausibleAge)/100; c-- {
+		long := fmt.Sprintf("%02d", c) + date
+		tm, err := parseBirthDate(long)
+		if err != nil {
+			continue
+		}
+		validDate = true
+		age := ageYears(tm, now)
+		if age < 0 || age >= MaxPlausibleAge || centenarian != (age >= 100) {
+			continue
+		}
+		return NewSSNFromString(long + last)
+	}
+	if !validDate {
+		return nil, ErrDate
+	}
+	return nil, ErrCentury
+}
+
+// SeparatorCorrect reports whether the separator in s, the SSN as written, matches the age on
+// the date of on: "+" for people aged 100 or more and "-" for everyone else.
+// Input without a separator, and the long form with "-", is always correct.
+func (n SSN) SeparatorCorrect(s string, on time.Time) bool {
+	switch {
+	case strings.ContainsRune(s, '+'):
+		return n.AgeYears(on) >= 100
+	case strings.ContainsRune(s, '-'):
+		if len(s) == 13 {
+			return true
+		}
+		return n.AgeYears(on) < 100
+	}
+	return true
+}
+
+// IsCenturyAmbiguous reports whether both 19xx and 20xx give a short form SSN a birth date
+// that is not in the future and younger than MaxPlausibleAge, on the date of now.
+// The separator is ignored since it is what people most often get wrong.
+func IsCenturyAmbiguous(shortForm string, now time.Time) bool {
+	var re = regexp.MustCompile(`^[0-9]{6}[-+]?[0-9]{4}$`)
+	if !re.MatchString(shortForm) {
+		return false
+	}
+	for _, century := range []string{"19", "20"} {
+		tm, err := parseBirthDate(century + shortForm[0:6])
+		if err != nil {
+			return false
+		}
+		if age := ageYears(tm, now); age < 0 || age >= MaxPlausibleAge {
+			return false
+		}
+	}
+	return true
+}
+
+// Combine makes a ssn type object from a century, such as 19, and a short form YYMMDD-XXXX,
+// YYMMDD+XXXX or YYMMDDXXXX, validated like NewSSNFromString. It is the inverse of Century and Short.
+func Combine(century int, short string) (*SSN, error) {
+	if century < 0 || century > 99 {
+		return nil, ErrArgument
+	}
+	var re = regexp.MustCompile(`^[0-9]{6}[-+]?[0-9]{4}$`)
+	if !re.MatchString(short) {
+		return nil, ErrFormat
+	}
+	return NewSSNFromString(fmt.Sprintf("%02d", century) + short[0:6] + "-" + short[len(short)-4:])
+}
+
+// ageYears returns the number of birthdays passed between birth and on
+func ageYears(birth, on time.Time) int {
+	y1, m1, d1 := birth.Date()
+	y2, m2, d2 := on.Date()
+	age := y2 - y1
+	if m2 < m1 || (m2 == m1 && d2 < d1) {
+		age--
+	}
+	return age
+}
+
+// NewSSN makes a ssn type object from a birth date and a three digit birth number and sets the checksum.
+// The last digit of birthNumber determines the gender, even for female and odd for male.
+func NewSSN(year int, month time.Month, day int, birthNumber int) (*SSN, error) {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if y, m, d := t.Date(); y != year || m != month || d != day || year < 0 || year > 9999 {
+		return nil, ErrDate
+	}
+	if birthNumber < 0 || birthNumber > 999 {
+		return nil, ErrBirthNumber
+	}
+	var ssn SSN
+	ssn.SetDate(t)
+	ssn[8], ssn[9], ssn[10] = birthNumber/100, birthNumber/10%10, birthNumber%10
+	ssn[11] = GetChecksum(ssn)
+	return &ssn, nil
+}
+
+// NewSSNGendered makes a ssn with a random birth number of the parity matching the gender
+func NewSSNGendered(year int, month time.Month, day int, g Gender) (*SSN, error) {
+	parity, ok := g.parity()
+	if !ok {
+		return nil, ErrGender
+	}
+	return NewSSN(year, month, day, rng.Intn(500)*2+parity)
+}
+
+// SiblingsForDate returns count distinct SSNs of the given gender born on the date of t.
+// There are 500 birth numbers per gender and day, nil is returned if count is out of that range
+// or the gender is unknown.
+func SiblingsForDate(t time.Time, g Gender, count int) []*SSN {
+	parity, ok := g.parity()
+	if !ok || count < 0 || count > 500 {
+		return nil
+	}
+	year, month, day := t.Date()
+	ssns := make([]*SSN, count)
+	for i, k := range rng.Perm(500)[:count] {
+		ssn, err := NewSSN(year, month, day, k*2+parity)
+		if err != nil {
+			return nil
+		}
+		ssns[i] = ssn
+	}
+	return ssns
+}
+
+// safeString returns s cut or padded with def to the length of def, counted in runes
 func safeString(s, def string) string {
-	l1, l2 := len(s), len(def)
-	if l1 >= l2 {
-		return s[:l2]
+	r1, r2 := []rune(s), []rune(def)
+	if len(r1) >= len(r2) {
+		return string(r1[:len(r2)])
 	}
-	return s + def[l1:l2]
+	return string(append(r1, r2[len(r1):]...))
 }
 
+// realPrefixes are the first two birth number digits, 00-99, for which no birth number
+// is in reservedBirthNumbers whatever the third digit
+var realPrefixes = func() []int {
+	prefixes := make([]int, 0, 100)
+	for k := 0; k < 100; k++ {
+		ok := true
+		for _, r := range reservedBirthNumbers {
+			if k*10 <= r[1] && k*10+9 >= r[0] {
+				ok = false
+			}
+		}
+		if ok {
+			prefixes = append(prefixes, k)
+		}
+	}
+	return prefixes
+}()
+
 func trySetDigitFromRune(r rune, i *int) {
 	switch r {
 	case '*':
 	case '?':
-		*i = rand.Intn(10)
+		*i = rng.Intn(10)
 	default:
 		if x, err := strconv.Atoi(string(r)); err == nil {
 			*i = x
@@ -105,21 +468,25 @@ func trySetDigitFromRune(r rune, i *int) {
 // m = random male
 // f = random female
 // s = safe (980-999) last digits
+// r = real looking last digits, never in the ranges of reservedBirthNumbers
 // c = get checksum
 func (n *SSN) SetLastDigits(s string) {
 	ss := []rune(safeString(s, "****"))
 	if (ss[0] == 's') || (ss[1] == 's') {
 		n[8] = 9
-		n[9] = rand.Intn(2) + 8
+		n[9] = rng.Intn(2) + 8
+	} else if (ss[0] == 'r') || (ss[1] == 'r') {
+		k := realPrefixes[rng.Intn(len(realPrefixes))]
+		n[8], n[9] = k/10, k%10
 	} else {
 		trySetDigitFromRune(ss[0], &n[8])
 		trySetDigitFromRune(ss[1], &n[9])
 	}
 	switch ss[2] {
 	case 'f':
-		n[10] = rand.Intn(5) * 2
+		n[10] = rng.Intn(5) * 2
 	case 'm':
-		n[10] = rand.Intn(5)*2 + 1
+		n[10] = rng.Intn(5)*2 + 1
 	default:
 		trySetDigitFromRune(ss[2], &n[10])
 	}
@@ -133,7 +500,8 @@ func (n *SSN) SetLastDigits(s string) {
 	}
 }
 
-// SetDate will set the time/date part of the SSN from a time.Time struct
+// SetDate will set the time/date part of the SSN from a time.Time struct.
+// The date is taken in the location of t, see SetDateInLocation for instants in other zones.
 func (n *SSN) SetDate(t time.Time) {
 	y := t.Year()
 	n[3], y = getDigit(y)
@@ -148,7 +516,14 @@ func (n *SSN) SetDate(t time.Time) {
 	n[6], _ = getDigit(d)
 }
 
-// String returns SSN in standard YYYYMMDD-XXXX formats
+// SetDateInLocation will set the time/date part of the SSN from the date of t in loc,
+// e.g. for a UTC instant of a birth in Sweden
+func (n *SSN) SetDateInLocation(t time.Time, loc *time.Location) {
+	n.SetDate(t.In(loc))
+}
+
+// String returns SSN in standard YYYYMMDD-XXXX formats.
+// It is cheap enough to call repeatedly.
 func (n SSN) String() string {
 	return n.Format(true, true)
 }
@@ -159,47 +534,418 @@ func (n SSN) Format(century, dash bool) string {
 	if !century {
 		i = 2
 	}
-	var b strings.Builder
-	for i < len(n) {
-		b.WriteString(strconv.Itoa(n[i]))
+	var buf [13]byte
+	b := buf[:0]
+	for ; i < len(n); i++ {
+		b = append(b, byte('0'+n[i]))
 		if i == 7 && dash {
-			b.WriteString("-")
+			b = append(b, '-')
 		}
-		i++
 	}
-	return b.String()
+	return string(b)
+}
+
+// Barcode returns the 12 digits without separator for barcode encoding, with the checksum
+// recomputed so that a value with a corrupted checksum still encodes correctly
+func (n SSN) Barcode() string {
+	n[11] = GetChecksum(n)
+	return n.Format(true, false)
+}
+
+// Short returns SSN in the short YYMMDD-XXXX format, always with a dash
+func (n SSN) Short() string {
+	return n.Format(false, true)
+}
+
+// SplitForInput returns the part before the separator, YYYYMMDD or YYMMDD as set by century,
+// and the last four digits, for forms with one input field for each
+func (n SSN) SplitForInput(century bool) (left, right string) {
+	s := n.Format(century, false)
+	return s[:len(s)-4], s[len(s)-4:]
+}
+
+// Century returns the first two digits of the birth year
+func (n SSN) Century() int {
+	return intSliceToInt(n[0:2])
+}
+
+// Spaced returns SSN in the YYYYMMDD - XXXX form used in official letters
+func (n SSN) Spaced() string {
+	s := n.Format(true, false)
+	return s[:8] + " - " + s[8:]
+}
+
+// LastFour returns the zero padded birth number and checksum, the XXXX of YYYYMMDD-XXXX
+func (n SSN) LastFour() string {
+	return n.Format(false, false)[6:]
+}
+
+// Masked returns SSN in the YYYYMMDD-**** form with the birth number and checksum hidden
+func (n SSN) Masked() string {
+	return n.Format(true, true)[:9] + "****"
+}
+
+// MaskLevel is how much of an SSN SSN.MaskLevel hides
+type MaskLevel int
+
+// Mask levels, from showing everything to hiding everything
+const (
+	// MaskNone shows the whole SSN, 19750930-1938
+	MaskNone MaskLevel = iota
+	// MaskDateOnly shows only the date, 19750930-****
+	MaskDateOnly
+	// MaskLastFour shows only the last four digits, ********-1938
+	MaskLastFour
+	// MaskFull hides all digits, ********-****
+	MaskFull
+)
+
+// MaskLevel returns SSN in YYYYMMDD-XXXX format with the digits hidden by level replaced by *
+func (n SSN) MaskLevel(level MaskLevel) string {
+	switch level {
+	case MaskDateOnly:
+		return n.Masked()
+	case MaskLastFour:
+		return "********-" + n.LastFour()
+	case MaskFull:
+		return "********-****"
+	}
+	return n.String()
+}
+
+// DemoShift returns n with its birth number moved by an even amount derived from seed, keeping
+// the date and the gender, and a recomputed checksum. It makes real-looking SSNs for screenshots,
+// and the same seed always maps an SSN to the same replacement.
+func (n SSN) DemoShift(seed int64) SSN {
+	shift := 2 * (rand.New(rand.NewSource(seed)).Intn(499) + 1)
+	b := (intSliceToInt(n[8:11]) + shift) % 1000
+	n[8], n[9], n[10] = b/100, b/10%10, b%10
+	n[11] = GetChecksum(n)
+	return n
+}
+
+// EqualString reports whether s is a representation of n, in either the long YYYYMMDD-XXXX
+// or the short YYMMDD-XXXX form, with or without separator. Short forms compare without century.
+// Unlike NewSSNFromString it does not allocate.
+func (n SSN) EqualString(s string) bool {
+	var i int
+	switch len(s) {
+	case 10, 11:
+		i = 2
+	case 12, 13:
+	default:
+		return false
+	}
+	sep := len(s)%2 == 1
+	for j := 0; i < len(n); i, j = i+1, j+1 {
+		if sep && i == 8 {
+			if s[j] != '-' && (s[j] != '+' || len(s) != 11) {
+				return false
+			}
+			j++
+		}
+		if int(s[j])-'0' != n[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // GetChecksum returns the Luhn algoritm checksum for the ssn
 func GetChecksum(n SSN) int {
+	return Luhn(n[2:11])
+}
+
+// ChecksumValid reports whether the stored checksum is the one computed from the other digits
+func (n SSN) ChecksumValid() bool {
+	return GetChecksum(n) == n[11]
+}
+
+// ChecksumStable is an alias of ChecksumValid, reading better in assertions after a mutation
+// that the value is still self-consistent
+func (n SSN) ChecksumStable() bool {
+	return n.ChecksumValid()
+}
+
+// ChecksumMode selects the digits GetChecksumMode computes the checksum over
+type ChecksumMode int
+
+// Checksum modes, ChecksumStandard is what GetChecksum uses
+const (
+	// ChecksumStandard uses the nine digits YYMMDDXXX
+	ChecksumStandard ChecksumMode = iota
+	// ChecksumFull also includes the century, as some nonstandard sources do
+	ChecksumFull
+)
+
+// GetChecksumMode returns the Luhn algoritm checksum for the ssn computed over the digits of mode
+func GetChecksumMode(n SSN, mode ChecksumMode) int {
+	if mode == ChecksumFull {
+		return Luhn(n[0:11])
+	}
+	return GetChecksum(n)
+}
+
+// Luhn returns the Luhn algorithm check digit for a number given as digits 0-9,
+// most significant first
+func Luhn(digits []int) int {
 	var sum int
-	for i := 2; i < 11; i++ {
-		sum += sumDigits(((i+1)%2 + 1) * n[i])
+	for i, k := len(digits)-1, 2; i >= 0; i, k = i-1, 3-k {
+		sum += sumDigits(k * digits[i])
+	}
+	return (10 - sum%10) % 10
+}
+
+// LuhnString returns the Luhn algorithm check digit for a string of digits,
+// e.g. to validate bankgiro or organisation numbers
+func LuhnString(s string) (int, error) {
+	if s == "" {
+		return 0, ErrDigits
+	}
+	digits := make([]int, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, ErrDigits
+		}
+		digits[i] = int(s[i] - '0')
+	}
+	return Luhn(digits), nil
+}
+
+// ChecksumForDigits returns the check digit for the nine digits YYMMDDNNN, which are what is typed
+// in the first ten characters of YYMMDD-NNNC, so a form can show the checksum before it is entered.
+// ErrDigits is returned unless there are exactly nine digits, each within 0-9.
+func ChecksumForDigits(digits []int) (int, error) {
+	if len(digits) != 9 {
+		return 0, ErrDigits
+	}
+	for _, d := range digits {
+		if d < 0 || d > 9 {
+			return 0, ErrDigits
+		}
 	}
-	result := (10 - sum%10) % 10
-	return result
+	return Luhn(digits), nil
 }
 
-func newRandomSSN() *SSN {
+// NewSSNFromTime will return a SSN born on the date of t with random last digits,
+// in the safe range (980-999) if safe is set, and a correct checksum
+func NewSSNFromTime(t time.Time, safe bool) *SSN {
 	var ssn SSN
-	t := GetRandomTime(time.Hour*24*365*100, 0)
 	ssn.SetDate(t)
-	ssn.SetLastDigits("???c")
+	if safe {
+		ssn.SetLastDigits("ss?c")
+	} else {
+		ssn.SetLastDigits("???c")
+	}
 	return &ssn
 }
 
+func randomAge100() time.Time {
+	return GetRandomTime(time.Hour*24*365*100, 0)
+}
+
 // NewRandomSSN will return a SSN of a 0-100 year old
 func NewRandomSSN() *SSN {
-	ssn := newRandomSSN()
-	ssn.SetLastDigits("???c")
-	return ssn
+	return NewSSNFromTime(randomAge100(), false)
 }
 
 // NewSafeRandomSSN will return a safe SSN of a 0-100 year old
 func NewSafeRandomSSN() *SSN {
-	ssn := newRandomSSN()
-	ssn.SetLastDigits("ss?c")
-	return ssn
+	return NewSSNFromTime(randomAge100(), true)
+}
+
+// NewRandomSSNForWeekday will return a SSN of a 0-100 year old born on the weekday wd
+func NewRandomSSNForWeekday(wd time.Weekday) *SSN {
+	t := randomAge100()
+	t = t.AddDate(0, 0, -(int(t.Weekday()-wd)+7)%7)
+	return NewSSNFromTime(t, false)
+}
+
+// NewRandomSSNsByWeekday will return perWeekday SSNs of 0-100 year olds born on each day of the week,
+// ordered from Sunday to Saturday. nil is returned for a negative perWeekday.
+func NewRandomSSNsByWeekday(perWeekday int) []*SSN {
+	if perWeekday < 0 {
+		return nil
+	}
+	ssns := make([]*SSN, 0, 7*perWeekday)
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		for i := 0; i < perWeekday; i++ {
+			ssns = append(ssns, NewRandomSSNForWeekday(wd))
+		}
+	}
+	return ssns
+}
+
+// NewRandomSSNs will return count SSNs like NewRandomSSN, all different if unique is set.
+// ErrArgument is returned for a negative count, or a count above the capacity of 100 years if unique.
+func NewRandomSSNs(count int, unique bool) ([]*SSN, error) {
+	// The same spread as randomAge100, reading the clock once for the capacity and every SSN
+	on, span := clock(), time.Hour*24*365*100
+	if count < 0 {
+		return nil, ErrArgument
+	}
+	if unique && count > Capacity(on.Add(-span), on, false) {
+		return nil, ErrArgument
+	}
+	// One backing array and a pre-sized set keep allocations constant however many are made
+	backing := make([]SSN, count)
+	ssns := make([]*SSN, count)
+	var seen map[SSN]struct{}
+	if unique {
+		seen = make(map[SSN]struct{}, count)
+	}
+	for i := 0; i < count; {
+		ssn := &backing[i]
+		ssn.SetDate(on.Add(-time.Duration(rng.Int63n(int64(span)))))
+		ssn.SetLastDigits("???c")
+		if unique {
+			if _, ok := seen[*ssn]; ok {
+				continue
+			}
+			seen[*ssn] = struct{}{}
+		}
+		ssns[i] = ssn
+		i++
+	}
+	return ssns, nil
+}
+
+// NewCohort will return count SSNs where the share of women is femaleRatio, rounded to whole people,
+// and everyone is between ageMin and ageMax years old on the date of on.
+// ErrArgument is returned for a ratio outside [0, 1] or ages that are negative, reversed or not plausible.
+func NewCohort(count int, femaleRatio float64, on time.Time, ageMin, ageMax int) ([]*SSN, error) {
+	if count < 0 || !(femaleRatio >= 0 && femaleRatio <= 1) ||
+		ageMin < 0 || ageMax < ageMin || ageMax >= MaxPlausibleAge {
+		return nil, ErrArgument
+	}
+	genders := make([]Gender, count)
+	females := int(math.Round(float64(count) * femaleRatio))
+	for i := range genders {
+		if i < females {
+			genders[i] = Female
+		} else {
+			genders[i] = Male
+		}
+	}
+	rng.Shuffle(count, func(i, j int) { genders[i], genders[j] = genders[j], genders[i] })
+	y, m, d := on.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	earliest := today.AddDate(-ageMax-1, 0, 1)
+	days := int(today.AddDate(-ageMin, 0, 0).Sub(earliest).Hours()/24) + 1
+	cohort := make([]*SSN, count)
+	for i, g := range genders {
+		birth := earliest.AddDate(0, 0, rng.Intn(days))
+		if age := ageYears(birth, today); age < ageMin || age > ageMax {
+			// Only happens around February 29, try another day
+			birth = earliest.AddDate(0, 0, days/2)
+		}
+		ssn, err := NewSSNGendered(birth.Year(), birth.Month(), birth.Day(), g)
+		if err != nil {
+			return nil, err
+		}
+		cohort[i] = ssn
+	}
+	return cohort, nil
+}
+
+// NewParentChild will return a child between 0 and childAgeMax years old and a parent born
+// parentAgeGapMin to parentAgeGapMin+5 years before the child.
+// ErrArgument is returned for a negative childAgeMax, a gap under 15 years or a parent that would not be plausible.
+func NewParentChild(childAgeMax, parentAgeGapMin int) (parent, child *SSN, err error) {
+	if childAgeMax < 0 || parentAgeGapMin < 15 || childAgeMax+parentAgeGapMin+5 >= MaxPlausibleAge {
+		return nil, nil, ErrArgument
+	}
+	y, m, d := clock().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	earliest := today.AddDate(-childAgeMax-1, 0, 1)
+	days := int(today.Sub(earliest).Hours()/24) + 1
+	childBirth := earliest.AddDate(0, 0, rng.Intn(days))
+	// Going back at least a day first keeps a February 29 from landing after the gap
+	parentBirth := childBirth.AddDate(0, 0, -1-rng.Intn(5*365)).AddDate(-parentAgeGapMin, 0, 0)
+	return NewSSNFromTime(parentBirth, false), NewSSNFromTime(childBirth, false), nil
+}
+
+// ForEachForDate calls fn with each of the 1000 SSNs born on the date of t, by birth number 000-999
+// and with correct checksums, without allocating. It stops early if fn returns false.
+func ForEachForDate(t time.Time, fn func(SSN) bool) {
+	var ssn SSN
+	ssn.SetDate(t)
+	for k := 0; k < 1000; k++ {
+		ssn[8], ssn[9], ssn[10] = k/100, k/10%10, k%10
+		ssn[11] = GetChecksum(ssn)
+		if !fn(ssn) {
+			return
+		}
+	}
+}
+
+// EachDay calls fn with a random valid SSN for every day from start to end, both days included.
+// It stops early if fn returns false.
+func EachDay(start, end time.Time, fn func(*SSN) bool) {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+	last := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	for day := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !fn(NewSSNFromTime(day, false)) {
+			return
+		}
+	}
+}
+
+// NewRealLookingSSN will return a SSN of a 0-100 year old whose birth number is outside
+// the reserved ranges, so it never collides with designated test numbers
+func NewRealLookingSSN() *SSN {
+	var ssn SSN
+	ssn.SetDate(randomAge100())
+	ssn.SetLastDigits("rr?c")
+	return &ssn
+}
+
+// Capacity returns how many distinct SSNs exist for birth dates from start to end, both days included.
+// Every day has 1000 birth numbers (000-999), or 20 (980-999) if only safe numbers are wanted.
+func Capacity(start, end time.Time, safe bool) int {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+	from := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	if to.Before(from) {
+		return 0
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	if safe {
+		return days * 20
+	}
+	return days * 1000
+}
+
+// ErrorKind names the validation NewInvalidSSN should make fail
+type ErrorKind int
+
+// The validations of NewSSNFromString, in the order they are checked
+const (
+	InvalidFormat ErrorKind = iota
+	InvalidDate
+	InvalidChecksum
+)
+
+// NewInvalidSSN returns a random SSN string that NewSSNFromString rejects with exactly the error
+// matching kind: ErrFormat, ErrDate or ErrChecksum. It panics on an unknown kind.
+func NewInvalidSSN(kind ErrorKind) string {
+	ssn := NewRandomSSN()
+	switch kind {
+	case InvalidFormat:
+		s := []byte(ssn.String())
+		s[9+rng.Intn(4)] = 'X'
+		return string(s)
+	case InvalidDate:
+		ssn[4], ssn[5] = 1, 3
+		ssn[11] = GetChecksum(*ssn)
+	case InvalidChecksum:
+		ssn[11] = (ssn[11] + 1 + rng.Intn(9)) % 10
+	default:
+		panic(fmt.Sprint("Unknown error kind ", kind))
+	}
+	return ssn.String()
 }
 
 func intSliceToInt(is []int) (sum int) {
@@ -209,8 +955,24 @@ func intSliceToInt(is []int) (sum int) {
 	return
 }
 
+// Date returns the date of birth, with 60 subtracted from the day of coordination numbers
 func (n SSN) Date() (year int, month time.Month, day int) {
-	return intSliceToInt(n[0:4]), time.Month(intSliceToInt(n[4:6])), intSliceToInt(n[6:8])
+	year, month, day = intSliceToInt(n[0:4]), time.Month(intSliceToInt(n[4:6])), intSliceToInt(n[6:8])
+	if day > 60 && day <= 91 {
+		day -= 60
+	}
+	return year, month, day
+}
+
+// Digits returns the 12 digits in a new slice that does not share memory with n
+func (n SSN) Digits() []int {
+	return append([]int(nil), n[:]...)
+}
+
+// Parts returns the date, the three digit birth number and the checksum of the SSN
+func (n SSN) Parts() (year, month, day, birthNumber, checksum int) {
+	y, m, d := n.Date()
+	return y, int(m), d, intSliceToInt(n[8:11]), n[11]
 }
 
 func intSliceToString(is []int) string {
@@ -221,18 +983,260 @@ func intSliceToString(is []int) string {
 	return b.String()
 }
 
+// Time returns the date of birth from Date as midnight UTC. It panics if that is not a valid date.
+// Go time ignores leap seconds and UTC has none to apply at midnight, so dates never shift by them.
 func (n SSN) Time() time.Time {
-	t, err := time.Parse("20060102", intSliceToString(n[0:8]))
-	if err != nil {
+	year, month, day := n.Date()
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if y, m, d := t.Date(); y != year || m != month || d != day {
 		panic(fmt.Sprint("SSN format invalid, cannot be parsed to Time", n))
 	}
 	return t
 }
 
+// Weekday returns the day of the week of the birth date
+func (n SSN) Weekday() time.Weekday {
+	return n.Time().Weekday()
+}
+
+// DayOfYear returns the day of the year of the birth date, 1-365 or 1-366 in leap years
+func (n SSN) DayOfYear() int {
+	return n.Time().YearDay()
+}
+
 func (n SSN) Age(now time.Time) time.Duration {
 	return now.Sub(n.Time())
 }
 
+// AgeDifference returns the absolute time between the birth dates of n and other
+func (n SSN) AgeDifference(other SSN) time.Duration {
+	d := n.Time().Sub(other.Time())
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// AgeYears returns the age in whole years on the date of on.
+// People born on February 29 turn a year older on March 1 in non-leap years.
+func (n SSN) AgeYears(on time.Time) int {
+	return ageYears(n.Time(), on)
+}
+
+// AgeMonths returns the age in whole months on the date of on. When the birth day does not exist
+// in a shorter month, like January 31 in February, that month is completed on the 1st of the next
+// month, the same rule as for February 29 in AgeYears.
+func (n SSN) AgeMonths(on time.Time) int {
+	y1, m1, d1 := n.Date()
+	y2, m2, d2 := on.Date()
+	months := (y2-y1)*12 + int(m2) - int(m1)
+	if d2 < d1 {
+		months--
+	}
+	return months
+}
+
+// AgeBreakdown returns the age on the date of on as whole years, months and days, such as 49 years,
+// 3 months and 13 days. Months are counted as in AgeMonths and the days are those since the last
+// completed month, so a birth day missing from a month is reached on the 1st of the next month.
+func (n SSN) AgeBreakdown(on time.Time) (years, months, days int) {
+	total := n.AgeMonths(on)
+	y1, m1, d1 := n.Date()
+	y, m, d := on.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	// time.Date normalizes month overflow, and day 0 is the last day of the previous month
+	anchor := time.Date(y1, m1+time.Month(total), d1, 0, 0, 0, 0, time.UTC)
+	if last := time.Date(y1, m1+time.Month(total)+1, 0, 0, 0, 0, 0, time.UTC); d1 > last.Day() {
+		anchor = last.AddDate(0, 0, 1)
+	}
+	return total / 12, total % 12, int(today.Sub(anchor).Hours() / 24)
+}
+
+// InsuranceAge returns the age reached during the calendar year of on (försäkringsålder),
+// which can be one more than AgeYears before the birthday
+func (n SSN) InsuranceAge(on time.Time) int {
+	year, _, _ := n.Date()
+	return on.Year() - year
+}
+
+// IsFutureBirth reports whether the birth date is after the date of on,
+// which points to a mistyped year in hand-built values
+func (n SSN) IsFutureBirth(on time.Time) bool {
+	return n.AgeYears(on) < 0
+}
+
+// IsMinor reports whether the person is under 18 on the date of on,
+// the day of the 18th birthday is no longer a minor
+func (n SSN) IsMinor(on time.Time) bool {
+	return n.AgeYears(on) < 18
+}
+
+// IsLeapDayBirth reports whether the person was born on February 29
+func (n SSN) IsLeapDayBirth() bool {
+	_, m, d := n.Date()
+	return m == time.February && d == 29
+}
+
+// NextBirthday returns the first birthday on or after the date of on, at midnight UTC.
+// People born on February 29 have their birthday on March 1 in non-leap years.
+func (n SSN) NextBirthday(on time.Time) time.Time {
+	_, month, day := n.Date()
+	y, m, d := on.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	// time.Date normalizes February 29 of a non-leap year to March 1
+	birthday := time.Date(y, month, day, 0, 0, 0, 0, time.UTC)
+	if birthday.Before(today) {
+		birthday = time.Date(y+1, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	return birthday
+}
+
+// IsBirthday reports whether the date of on is a birthday.
+// People born on February 29 have their birthday on March 1 in non-leap years.
+func (n SSN) IsBirthday(on time.Time) bool {
+	y, m, d := on.Date()
+	return n.NextBirthday(on).Equal(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
+
+// RetirementDate returns the date the person reaches retirementAge.
+// People born on February 29 reach it on March 1 in non-leap years.
+func (n SSN) RetirementDate(retirementAge int) time.Time {
+	return n.Time().AddDate(retirementAge, 0, 0)
+}
+
+// DaysToNextRoundAge returns the next age that is a multiple of interval, such as 50 for an
+// interval of 10 and someone aged 43, and the number of days from the date of on until that
+// birthday. On the round birthday itself it returns that age and 0 days.
+// It panics if interval is not positive.
+func (n SSN) DaysToNextRoundAge(on time.Time, interval int) (age, days int) {
+	if interval <= 0 {
+		panic("DaysToNextRoundAge needs a positive interval")
+	}
+	y, m, d := on.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	current := n.AgeYears(on)
+	age = (current/interval + 1) * interval
+	if current%interval == 0 && n.IsBirthday(on) {
+		age = current
+	}
+	days = int(n.RetirementDate(age).Sub(today).Hours() / 24)
+	return age, days
+}
+
 func (n SSN) Female() bool {
 	return n[10]%2 == 0
 }
+
+// Kind returns KindCoordination for coordination numbers, which have 60 added to the day,
+// and KindPersonnummer otherwise. Reserve numbers are told apart by ParseAny.
+func (n SSN) Kind() Kind {
+	if day := intSliceToInt(n[6:8]); day > 60 && day <= 91 {
+		return KindCoordination
+	}
+	return KindPersonnummer
+}
+
+// Gender as encoded in the ninth digit of an SSN
+type Gender int
+
+// The genders an SSN can encode, the zero value means no gender is given
+const (
+	Female Gender = iota + 1
+	Male
+)
+
+func (g Gender) String() string {
+	switch g {
+	case Female:
+		return "female"
+	case Male:
+		return "male"
+	}
+	return "unknown"
+}
+
+// parity returns the parity of the ninth digit for the gender
+func (g Gender) parity() (int, bool) {
+	switch g {
+	case Female:
+		return 0, true
+	case Male:
+		return 1, true
+	}
+	return 0, false
+}
+
+// DerivedGender returns the gender given by the parity of the ninth digit
+func (n SSN) DerivedGender() Gender {
+	if n.Female() {
+		return Female
+	}
+	return Male
+}
+
+// CouldBeTwin reports whether other has the same birth date but a different birth number
+func (n SSN) CouldBeTwin(other SSN) bool {
+	y1, m1, d1 := n.Date()
+	y2, m2, d2 := other.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 && intSliceToInt(n[8:11]) != intSliceToInt(other[8:11])
+}
+
+// SamePerson reports whether other has the same date of birth and birth number, counting a
+// coordination number and a personnummer as the same person when the dates agree after
+// subtracting 60 from the coordination day. Checksums are ignored as they differ between the two.
+func (n SSN) SamePerson(other SSN) bool {
+	y1, m1, d1 := n.Date()
+	y2, m2, d2 := other.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 && n[8] == other[8] && n[9] == other[9] && n[10] == other[10]
+}
+
+// EqualIgnoreChecksum reports whether other has the same date and birth number, whatever the checksums
+func (n SSN) EqualIgnoreChecksum(other SSN) bool {
+	n[11], other[11] = 0, 0
+	return n == other
+}
+
+// LooksSynthetic reports whether the last four digits follow an obvious pattern from
+// data entry, repeated (1111), ascending (1234) or descending (4321)
+func (n SSN) LooksSynthetic() bool {
+	step := n[9] - n[8]
+	if step < -1 || step > 1 {
+		return false
+	}
+	for i := 10; i < len(n); i++ {
+		if n[i]-n[i-1] != step {
+			return false
+		}
+	}
+	return true
+}
+
+// reservedBirthNumbers are the birth number ranges withheld from allocation to real people
+var reservedBirthNumbers = [][2]int{
+	{980, 999},
+}
+
+// inReservedBirthNumbers reports whether the birth number of n is in reservedBirthNumbers
+func (n SSN) inReservedBirthNumbers() bool {
+	k := intSliceToInt(n[8:11])
+	for _, r := range reservedBirthNumbers {
+		if k >= r[0] && k <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSafe reports whether the birth number is in the safe range 980-999 used for test data,
+// as produced by the "s" pattern of SetLastDigits
+func (n SSN) IsSafe() bool {
+	return n.inReservedBirthNumbers()
+}
+
+// IsStatisticalReserved reports whether the birth number is in a range withheld from allocation
+// to real people, so statistics on real people can exclude it. The only such range with a
+// documented source is 980-999, which Skatteverket keeps for test data, so for now it agrees
+// with IsSafe. No ranges reserved by Statistics Sweden are known to be published.
+func (n SSN) IsStatisticalReserved() bool {
+	return n.inReservedBirthNumbers()
+}